@@ -0,0 +1,165 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"F1_catalog/ergast"
+)
+
+// NewHandler отдаёт каталог в виде JSON REST API поверх того же клиента, что и GUI:
+//
+//	GET /seasons/{year}/races
+//	GET /seasons/{year}/rounds/{round}/results
+//	GET /drivers/{id}
+func NewHandler(client ergast.Client) http.Handler {
+	h := &handler{client: client}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /seasons/{year}/races", h.races)
+	mux.HandleFunc("GET /seasons/{year}/rounds/{round}/results", h.results)
+	mux.HandleFunc("GET /drivers/{id}", h.driver)
+	return mux
+}
+
+type handler struct {
+	client ergast.Client
+}
+
+func (h *handler) races(w http.ResponseWriter, r *http.Request) {
+	year, ok := numberParam(w, r, "year")
+	if !ok {
+		return
+	}
+	races, err := h.client.Season(r.Context(), year)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(races) == 0 {
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("no races found for season %s", year))
+		return
+	}
+	normalized := make([]Race, 0, len(races))
+	for _, race := range races {
+		normalized = append(normalized, newRace(race))
+	}
+	writeJSON(w, r, normalized)
+}
+
+func (h *handler) results(w http.ResponseWriter, r *http.Request) {
+	year, ok := numberParam(w, r, "year")
+	if !ok {
+		return
+	}
+	round, ok := numberParam(w, r, "round")
+	if !ok {
+		return
+	}
+	results, err := h.client.Results(r.Context(), year, round)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(results) == 0 {
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("no results for season %s round %s", year, round))
+		return
+	}
+	normalized := make([]Result, 0, len(results))
+	for _, result := range results {
+		normalized = append(normalized, newResult(result))
+	}
+	writeJSON(w, r, normalized)
+}
+
+func (h *handler) driver(w http.ResponseWriter, r *http.Request) {
+	id, ok := idParam(w, r, "id")
+	if !ok {
+		return
+	}
+	driver, err := h.client.Driver(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, r, newDriver(*driver))
+}
+
+// numberParam проверяет, что параметр пути — число (год, номер этапа)
+func numberParam(w http.ResponseWriter, r *http.Request, name string) (string, bool) {
+	value := r.PathValue(name)
+	if _, err := strconv.Atoi(value); err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("%s must be a number", name))
+		return "", false
+	}
+	return value, true
+}
+
+// idPattern — формат идентификаторов Ergast: hamilton, max_verstappen, red_bull
+var idPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// idParam проверяет идентификатор пилота, команды или трассы, чтобы он не менял путь запроса к API
+func idParam(w http.ResponseWriter, r *http.Request, name string) (string, bool) {
+	value := r.PathValue(name)
+	if !idPattern.MatchString(value) {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("%s must contain only lowercase letters, digits and underscores", name))
+		return "", false
+	}
+	return value, true
+}
+
+// writeJSON отдаёт ответ с ETag; если клиент прислал тот же ETag — 304 без тела
+func writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// etagMatches проверяет If-None-Match по RFC 9110: список через запятую, "*" и слабое
+// сравнение (W/"x" совпадает с "x")
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError переводит ошибку клиента в HTTP-статус
+func writeError(w http.ResponseWriter, err error) {
+	var statusErr *ergast.StatusError
+	switch {
+	case errors.Is(err, ergast.ErrNotFound), errors.Is(err, ergast.ErrNotSynced):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+	case errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound:
+		writeJSONError(w, http.StatusNotFound, err.Error())
+	default:
+		writeJSONError(w, http.StatusBadGateway, err.Error()) // ни один источник данных не ответил
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"F1_catalog/ergast"
+)
+
+// fakeClient отвечает на запросы REST API без сети; остальные методы Client не нужны
+type fakeClient struct {
+	ergast.Client
+}
+
+func (fakeClient) Season(ctx context.Context, year string) ([]ergast.Race, error) {
+	if year != "2023" {
+		return nil, nil
+	}
+	return []ergast.Race{{Season: "2023", Round: "1", RaceName: "Bahrain Grand Prix", Date: "2023-03-05", Time: "15:00:00Z"}}, nil
+}
+
+func (fakeClient) Results(ctx context.Context, year, round string) ([]ergast.RaceResult, error) {
+	if year != "2023" || round != "1" {
+		return nil, ergast.ErrNotFound
+	}
+	var result ergast.RaceResult
+	err := json.Unmarshal([]byte(`{"position": "1", "points": "25", "Driver": {"driverId": "max_verstappen"},
+		"Time": {"millis": "5636736", "time": "1:33:56.736"},
+		"FastestLap": {"rank": "6", "lap": "44", "Time": {"time": "1:36.236"}}}`), &result)
+	return []ergast.RaceResult{result}, err
+}
+
+func (fakeClient) Driver(ctx context.Context, driverID string) (*ergast.Driver, error) {
+	if driverID != "hamilton" {
+		return nil, ergast.ErrNotFound
+	}
+	return &ergast.Driver{DriverID: "hamilton", GivenName: "Lewis", FamilyName: "Hamilton", DateOfBirth: "1985-01-07"}, nil
+}
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(NewHandler(fakeClient{}))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/drivers/hamilton")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag in the response")
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		ifNoneMatch string
+		wantStatus  int
+		wantBody    string // подстрока тела ответа
+	}{
+		{"races", "/seasons/2023/races", "", http.StatusOK, `"name":"Bahrain Grand Prix"`},
+		{"driver", "/drivers/hamilton", "", http.StatusOK, `"dateOfBirth":"1985-01-07"`},
+		{"duration in milliseconds", "/seasons/2023/rounds/1/results", "", http.StatusOK, `"time":5636736`},
+		{"lap time in milliseconds", "/seasons/2023/rounds/1/results", "", http.StatusOK, `"fastestLap":{"rank":6,"lap":44,"time":96236}`},
+		{"etag matches", "/drivers/hamilton", etag, http.StatusNotModified, ""},
+		{"etag in a list", "/drivers/hamilton", `"other", ` + etag, http.StatusNotModified, ""},
+		{"weak etag", "/drivers/hamilton", "W/" + etag, http.StatusNotModified, ""},
+		{"any etag", "/drivers/hamilton", "*", http.StatusNotModified, ""},
+		{"stale etag", "/drivers/hamilton", `"other"`, http.StatusOK, `"id":"hamilton"`},
+		{"year not a number", "/seasons/abc/races", "", http.StatusBadRequest, "year must be a number"},
+		{"round not a number", "/seasons/2023/rounds/first/results", "", http.StatusBadRequest, "round must be a number"},
+		{"invalid driver id", "/drivers/Hamilton", "", http.StatusBadRequest, "id must contain"},
+		{"escaped path in driver id", "/drivers/..%2F2023", "", http.StatusBadRequest, "id must contain"},
+		{"unknown driver", "/drivers/senna", "", http.StatusNotFound, ergast.ErrNotFound.Error()},
+		{"unknown round", "/seasons/2023/rounds/7/results", "", http.StatusNotFound, ergast.ErrNotFound.Error()},
+		{"season without races", "/seasons/1900/races", "", http.StatusNotFound, "no races found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantStatus == http.StatusNotModified {
+				if len(body) != 0 {
+					t.Errorf("304 with a body: %s", body)
+				}
+				return
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("body %s, want it to contain %s", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"F1_catalog/ergast"
+)
+
+// Нормализованные ответы REST API: числа — числами, время — временем,
+// вместо строк Ergast
+
+type Race struct {
+	Season   int        `json:"season"`
+	Round    int        `json:"round"`
+	Name     string     `json:"name"`
+	Start    *time.Time `json:"start,omitempty"` // нет, если дата гонки ещё не объявлена
+	Circuit  Circuit    `json:"circuit"`
+	URL      string     `json:"url"`
+	Sessions []Session  `json:"sessions"`
+}
+
+type Session struct {
+	Name  string    `json:"name"`
+	Start time.Time `json:"start"`
+}
+
+type Circuit struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	URL      string  `json:"url"`
+	Locality string  `json:"locality"`
+	Country  string  `json:"country"`
+	Lat      float64 `json:"lat"`
+	Long     float64 `json:"long"`
+}
+
+type Result struct {
+	Position    int            `json:"position"`
+	Number      int            `json:"number"`
+	Grid        int            `json:"grid"`
+	Laps        int            `json:"laps"`
+	Points      float64        `json:"points"`
+	Status      string         `json:"status"`
+	Time        *Duration      `json:"time,omitempty"` // полное время гонки, только у классифицированных на круге лидера
+	FastestLap  *FastestLap    `json:"fastestLap,omitempty"`
+	Driver      DriverRef      `json:"driver"`
+	Constructor ConstructorRef `json:"constructor"`
+}
+
+type FastestLap struct {
+	Rank            int      `json:"rank"`
+	Lap             int      `json:"lap"`
+	Time            Duration `json:"time"`
+	AverageSpeedKPH float64  `json:"averageSpeedKph,omitempty"`
+}
+
+type DriverRef struct {
+	ID   string `json:"id"`
+	Code string `json:"code,omitempty"`
+	Name string `json:"name"`
+}
+
+type ConstructorRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Driver struct {
+	ID          string `json:"id"`
+	Code        string `json:"code,omitempty"`
+	Number      int    `json:"number,omitempty"`
+	GivenName   string `json:"givenName"`
+	FamilyName  string `json:"familyName"`
+	DateOfBirth *Date  `json:"dateOfBirth,omitempty"`
+	Nationality string `json:"nationality"`
+	URL         string `json:"url"`
+}
+
+// Duration сериализуется в целое число миллисекунд: 5504742 для 1:31:44.742
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).Milliseconds())
+}
+
+// Date сериализуется как "2006-01-02"
+type Date time.Time
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(d).Format("2006-01-02"))
+}
+
+func newRace(race ergast.Race) Race {
+	normalized := Race{
+		Season: atoi(race.Season),
+		Round:  atoi(race.Round),
+		Name:   race.RaceName,
+		URL:    race.URL,
+		Circuit: Circuit{
+			ID:       race.Circuit.CircuitID,
+			Name:     race.Circuit.CircuitName,
+			URL:      race.Circuit.URL,
+			Locality: race.Circuit.Location.Locality,
+			Country:  race.Circuit.Location.Country,
+			Lat:      atof(race.Circuit.Location.Lat),
+			Long:     atof(race.Circuit.Location.Long),
+		},
+		Sessions: []Session{},
+	}
+	for _, session := range race.Sessions() {
+		normalized.Sessions = append(normalized.Sessions, Session{Name: session.Name, Start: session.Start})
+	}
+	if start, ok := race.Start(); ok {
+		normalized.Start = &start
+	}
+	return normalized
+}
+
+func newResult(result ergast.RaceResult) Result {
+	normalized := Result{
+		Position: atoi(result.Position),
+		Number:   atoi(result.Number),
+		Grid:     atoi(result.Grid),
+		Laps:     atoi(result.Laps),
+		Points:   atof(result.Points),
+		Status:   result.Status,
+		Driver: DriverRef{
+			ID:   result.Driver.DriverID,
+			Code: result.Driver.Code,
+			Name: result.Driver.GivenName + " " + result.Driver.FamilyName,
+		},
+		Constructor: ConstructorRef{ID: result.Constructor.ConstructorID, Name: result.Constructor.Name},
+	}
+	if result.Time != nil && result.Time.Millis != "" {
+		if millis, err := strconv.ParseInt(result.Time.Millis, 10, 64); err == nil {
+			d := Duration(time.Duration(millis) * time.Millisecond)
+			normalized.Time = &d
+		}
+	}
+	if fastest := result.FastestLap; fastest != nil {
+		lapTime, ok := ergast.ParseLapTime(fastest.Time.Time)
+		if !ok {
+			return normalized // круг без времени — сравнивать нечего
+		}
+		normalized.FastestLap = &FastestLap{
+			Rank:            atoi(fastest.Rank),
+			Lap:             atoi(fastest.Lap),
+			Time:            Duration(lapTime),
+			AverageSpeedKPH: atof(fastest.AverageSpeed.Speed),
+		}
+	}
+	return normalized
+}
+
+func newDriver(driver ergast.Driver) Driver {
+	normalized := Driver{
+		ID:          driver.DriverID,
+		Code:        driver.Code,
+		Number:      atoi(driver.PermanentNumber),
+		GivenName:   driver.GivenName,
+		FamilyName:  driver.FamilyName,
+		Nationality: driver.Nationality,
+		URL:         driver.URL,
+	}
+	if dob, err := time.Parse("2006-01-02", driver.DateOfBirth); err == nil {
+		date := Date(dob)
+		normalized.DateOfBirth = &date
+	}
+	return normalized
+}
+
+// atoi и atof возвращают 0 для пустых и нечисловых строк Ergast
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func atof(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
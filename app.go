@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// App — общее для всех окон состояние: клиенты API, локальная копия и офлайн-режим
+type App struct {
+	fyneApp fyne.App
+
+	client        ergast.Client          // источник данных (Ergast-совместимое API)
+	onlineClient  ergast.Client          // только сеть — из него синхронизируется локальная копия
+	offlineStore  *ergast.Store          // локальная копия всех сезонов
+	offlineClient *ergast.FallbackClient // сеть с откатом на локальную копию
+
+	offline      binding.Bool   // принудительный офлайн-режим, общий для всех окон
+	sessionTimes binding.String // основное время сессий: yourTime или circuitTime, хранится в настройках
+
+	notify        binding.Bool // напоминать о сессиях; как и notifyMinutes, хранится в настройках
+	notifyMinutes binding.Int
+	keepInTray    binding.Bool // меню в трее и работа без открытых окон; хранится в настройках
+	tray          *tray        // nil, пока меню в трее не добавлено
+	masterWindow  fyne.Window  // первое окно, если при запуске трей был выключен: его закрытие завершает приложение
+
+	schedule         []ergast.Race // календарь текущего сезона для "Next up", напоминаний и трея; поля ниже — только из UI-потока
+	scheduleLoadedAt time.Time
+	scheduleLoading  bool
+	notified         map[string]bool             // сессии, о которых уже напомнили
+	clockHandlers    map[any]func(now time.Time) // окна и трей, обновляются каждую секунду
+
+	syncMu  sync.Mutex
+	syncing bool
+}
+
+// newClients собирает источники данных. Не зависит от Fyne, поэтому
+// используется и окнами, и командной строкой
+func newClients() (*ergast.HTTPClient, *ergast.Store, *ergast.FallbackClient) {
+	// зеркала через запятую, после Jolpica
+	httpClient := ergast.NewHTTPClient(strings.Split(os.Getenv("F1_CATALOG_MIRRORS"), ",")...)
+	if cacheDir, err := os.UserCacheDir(); err == nil { // XDG_CACHE_HOME или ~/.cache
+		httpClient.Cache = ergast.NewCache(filepath.Join(cacheDir, "f1_catalog"))
+	}
+
+	storeDir := "f1_catalog_offline"
+	if configDir, err := os.UserConfigDir(); err == nil { // XDG_CONFIG_HOME или ~/.config
+		storeDir = filepath.Join(configDir, "f1_catalog", "offline")
+	}
+	store := ergast.NewStore(storeDir)
+	return httpClient, store, ergast.NewFallbackClient(httpClient, store) // при недоступности сети читаем локальную копию
+}
+
+func newApp() *App {
+	httpClient, store, fallbackClient := newClients()
+	a := &App{
+		fyneApp:       app.NewWithID("io.github.stepa2005.f1catalog"),
+		client:        fallbackClient,
+		onlineClient:  httpClient,
+		offlineStore:  store,
+		offlineClient: fallbackClient,
+	}
+
+	a.sessionTimes = binding.BindPreferenceString(sessionTimesPreference, a.fyneApp.Preferences())
+	a.notify = binding.BindPreferenceBool("notifySessions", a.fyneApp.Preferences())
+	a.notifyMinutes = binding.BindPreferenceInt("notifyMinutes", a.fyneApp.Preferences())
+	a.keepInTray = binding.BindPreferenceBool("keepInTray", a.fyneApp.Preferences())
+	if minutes, _ := a.notifyMinutes.Get(); minutes <= 0 {
+		a.notifyMinutes.Set(defaultNotifyMinutes)
+	}
+	a.notified = make(map[string]bool)
+	a.clockHandlers = make(map[any]func(now time.Time))
+	a.fyneApp.Lifecycle().SetOnStarted(a.startClock)
+	a.offline = binding.NewBool()
+	a.offline.AddListener(binding.NewDataListener(func() {
+		offline, _ := a.offline.Get()
+		a.offlineClient.SetOffline(offline)
+	}))
+	return a
+}
+
+// NewSeasonWindow открывает ещё одно окно каталога со своим сезоном
+func (a *App) NewSeasonWindow() *SeasonView {
+	view := newSeasonView(a, a.fyneApp.NewWindow("F1 Race Catalog"))
+	view.window.Show()
+	return view
+}
+
+// OpenSeason открывает окно с сезоном year; если round не пустой, в нём сразу выбран этап round
+func (a *App) OpenSeason(year, round string) {
+	view := a.NewSeasonWindow()
+	view.pendingRound = round
+	view.seasonEntryForInputScreen.SetText(year)
+	view.loadDataForYear(year, view.statusTextForInputScreen, true)
+}
+
+// Sync скачивает все сезоны в локальную копию. Одновременно идёт только одна синхронизация
+func (a *App) Sync(status binding.String, done func()) {
+	a.syncMu.Lock()
+	if a.syncing {
+		a.syncMu.Unlock()
+		status.Set("Offline sync is already running in another window.")
+		done()
+		return
+	}
+	a.syncing = true
+	a.syncMu.Unlock()
+
+	go func() { // синхронизация всех сезонов занимает долго, UI не блокируем
+		lastSeason := time.Now().Year()
+		err := ergast.Sync(context.Background(), a.onlineClient, a.offlineStore, 1950, lastSeason, func(year int) {
+			status.Set(fmt.Sprintf("Syncing offline data: season %d of %d...", year, lastSeason))
+		})
+		if err != nil {
+			status.Set("Offline sync failed: " + err.Error())
+		} else {
+			status.Set(fmt.Sprintf("Offline data synced for seasons 1950-%d.", lastSeason))
+		}
+		a.syncMu.Lock()
+		a.syncing = false
+		a.syncMu.Unlock()
+		fyne.Do(done)
+	}()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// chartBar — столбец: подпись под ним, значение (высота) и надпись над ним
+type chartBar struct {
+	label   string
+	value   float64
+	caption string
+	color   color.Color
+}
+
+// barChart — простая столбчатая диаграмма, например очки пилота по сезонам
+type barChart struct {
+	widget.BaseWidget
+
+	bars []chartBar
+}
+
+func newBarChart() *barChart {
+	chart := &barChart{}
+	chart.ExtendBaseWidget(chart)
+	return chart
+}
+
+// SetBars заменяет данные диаграммы. Вызывается в UI-потоке
+func (c *barChart) SetBars(bars []chartBar) {
+	c.bars = bars
+	c.Refresh()
+}
+
+func (c *barChart) CreateRenderer() fyne.WidgetRenderer {
+	r := &barChartRenderer{chart: c}
+	r.rebuild()
+	return r
+}
+
+type barChartRenderer struct {
+	chart    *barChart
+	rects    []*canvas.Rectangle
+	labels   []*canvas.Text
+	captions []*canvas.Text
+	maxValue float64
+	objects  []fyne.CanvasObject
+}
+
+func (r *barChartRenderer) rebuild() {
+	r.rects, r.labels, r.captions, r.objects = nil, nil, nil, nil
+	r.maxValue = 0
+	textColor := theme.Color(theme.ColorNameForeground)
+	for _, bar := range r.chart.bars {
+		r.maxValue = max(r.maxValue, bar.value)
+		rect := canvas.NewRectangle(bar.color)
+		label := canvas.NewText(bar.label, textColor)
+		label.TextSize = theme.CaptionTextSize()
+		caption := canvas.NewText(bar.caption, textColor)
+		caption.TextSize = theme.CaptionTextSize()
+		r.rects = append(r.rects, rect)
+		r.labels = append(r.labels, label)
+		r.captions = append(r.captions, caption)
+		r.objects = append(r.objects, rect, label, caption)
+	}
+}
+
+func (r *barChartRenderer) Layout(size fyne.Size) {
+	if len(r.rects) == 0 {
+		return
+	}
+	textHeight := fyne.MeasureText("0", theme.CaptionTextSize(), fyne.TextStyle{}).Height
+	plotHeight := size.Height - 2*textHeight // место под подписи снизу и сверху
+	slot := size.Width / float32(len(r.rects))
+	barWidth := max(slot*0.7, 1)
+	for i, rect := range r.rects {
+		height := float32(0)
+		if r.maxValue > 0 {
+			height = plotHeight * float32(r.chart.bars[i].value/r.maxValue)
+		}
+		x := slot*float32(i) + (slot-barWidth)/2
+		top := textHeight + plotHeight - height
+		rect.Move(fyne.NewPos(x, top))
+		rect.Resize(fyne.NewSize(barWidth, height))
+
+		labelSize := r.labels[i].MinSize()
+		r.labels[i].Resize(labelSize)
+		r.labels[i].Move(fyne.NewPos(slot*float32(i)+(slot-labelSize.Width)/2, size.Height-textHeight))
+		captionSize := r.captions[i].MinSize()
+		r.captions[i].Resize(captionSize)
+		r.captions[i].Move(fyne.NewPos(slot*float32(i)+(slot-captionSize.Width)/2, top-textHeight))
+	}
+}
+
+// MinSize — по 36px на столбец; в окнах профилей диаграмма лежит в горизонтальной прокрутке
+func (r *barChartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(float32(len(r.chart.bars))*36, 180)
+}
+
+func (r *barChartRenderer) Refresh() {
+	r.rebuild()
+	r.Layout(r.chart.Size())
+	canvas.Refresh(r.chart)
+}
+
+func (r *barChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *barChartRenderer) Destroy() {}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"F1_catalog/ergast"
+)
+
+// Общая статистика карьеры для профилей пилотов и команд
+
+// notStarted — статусы Ergast у пилотов, которые в гонку так и не стартовали
+var notStarted = map[string]bool{
+	"Did not qualify":    true,
+	"Did not prequalify": true,
+	"Did not start":      true,
+	"Withdrew":           true,
+}
+
+// finished — машина доехала до финиша (в том числе отстав на круги)
+func finished(status string) bool {
+	return status == "Finished" || status == "Lapped" || strings.HasPrefix(status, "+")
+}
+
+// careerSeason — итоги одного сезона пилота или команды
+type careerSeason struct {
+	season   string
+	teams    []ergast.Constructor
+	drivers  []ergast.Driver
+	starts   int
+	wins     int
+	podiums  int
+	poles    int     // поулы, см. poleSitters
+	dnfs     int     // сходы стартовавших машин
+	points   float64 // из итогового зачёта, если он есть, иначе сумма очков за гонки
+	position string  // место в чемпионате
+}
+
+// championshipResult — итог сезона в личном или командном зачёте
+type championshipResult struct {
+	position     string
+	positionText string
+	points       string
+}
+
+// career — сводка карьеры по результатам гонок и итогам сезонов
+type career struct {
+	seasons         []careerSeason
+	starts          int
+	wins            int
+	podiums         int
+	poles           int
+	dnfs            int
+	points          float64
+	titles          []string // сезоны, выигранные в чемпионате
+	bestFinish      int      // лучшее место в чемпионате, 0 — нет данных
+	bestFinishYears []string
+	lastRace        ergast.Race
+}
+
+// poleSitters — обладатели поулов по данным квалификаций (Client.Poles)
+type poleSitters struct {
+	seasons map[string]bool                    // сезоны, по которым есть данные квалификаций
+	races   map[string]ergast.QualifyingResult // "сезон/этап" -> поул
+}
+
+func newPoleSitters(poles []ergast.Race) poleSitters {
+	p := poleSitters{seasons: make(map[string]bool), races: make(map[string]ergast.QualifyingResult, len(poles))}
+	for _, race := range poles {
+		if len(race.QualifyingResults) == 0 {
+			continue
+		}
+		p.seasons[race.Season] = true
+		p.races[race.Season+"/"+race.Round] = race.QualifyingResults[0]
+	}
+	return p
+}
+
+// tookPole — взяла ли машина result поул. Для сезонов без данных квалификаций судим
+// по первому месту решётки, хотя штрафы и старты с пит-лейна его сдвигают
+func (p poleSitters) tookPole(race ergast.Race, result ergast.RaceResult) bool {
+	if !p.seasons[race.Season] {
+		return result.Grid == "1"
+	}
+	pole, ok := p.races[race.Season+"/"+race.Round]
+	return ok && pole.Driver.DriverID == result.Driver.DriverID && pole.Constructor.ConstructorID == result.Constructor.ConstructorID
+}
+
+// loadPoles загружает поулы для профиля. Без них поулы считаются по стартовой решётке,
+// поэтому ошибка профиль не ломает
+func (a *App) loadPoles(ctx context.Context) []ergast.Race {
+	poles, err := a.client.Poles(ctx)
+	if err != nil {
+		fmt.Println("Error loading poles:", err)
+	}
+	return poles
+}
+
+// newCareer считает сводку. poles — ответ Client.Poles, standings — итоговое место и очки по сезонам
+func newCareer(races, poles []ergast.Race, standings map[string]championshipResult) career {
+	var c career
+	poleSitters := newPoleSitters(poles)
+	bySeason := make(map[string]*careerSeason)
+	var order []string
+	for _, race := range races {
+		if len(race.Results) == 0 {
+			continue
+		}
+		season, ok := bySeason[race.Season]
+		if !ok {
+			season = &careerSeason{season: race.Season}
+			bySeason[race.Season] = season
+			order = append(order, race.Season)
+		}
+		for _, result := range race.Results { // у команды — по строке на каждую машину
+			if !slices.ContainsFunc(season.teams, func(t ergast.Constructor) bool { return t.ConstructorID == result.Constructor.ConstructorID }) {
+				season.teams = append(season.teams, result.Constructor)
+			}
+			if !slices.ContainsFunc(season.drivers, func(d ergast.Driver) bool { return d.DriverID == result.Driver.DriverID }) {
+				season.drivers = append(season.drivers, result.Driver)
+			}
+			if notStarted[result.Status] {
+				continue
+			}
+			season.starts++
+			points, _ := strconv.ParseFloat(result.Points, 64)
+			season.points += points
+			switch result.Position {
+			case "1":
+				season.wins++
+				season.podiums++
+			case "2", "3":
+				season.podiums++
+			}
+			if poleSitters.tookPole(race, result) {
+				season.poles++
+			}
+			if !finished(result.Status) {
+				season.dnfs++
+			}
+		}
+		c.lastRace = race
+	}
+	sort.Strings(order) // ответ Ergast и так по порядку, но локальная копия может быть неполной
+
+	for _, year := range order {
+		season := bySeason[year]
+		if standing, ok := standings[year]; ok {
+			season.position = standing.positionText
+			if points, err := strconv.ParseFloat(standing.points, 64); err == nil {
+				season.points = points // с учётом отброшенных результатов старых сезонов
+			}
+			if position, err := strconv.Atoi(standing.position); err == nil {
+				switch {
+				case c.bestFinish == 0 || position < c.bestFinish:
+					c.bestFinish, c.bestFinishYears = position, []string{year}
+				case position == c.bestFinish:
+					c.bestFinishYears = append(c.bestFinishYears, year)
+				}
+				if position == 1 {
+					c.titles = append(c.titles, year)
+				}
+			}
+		}
+		c.starts += season.starts
+		c.wins += season.wins
+		c.podiums += season.podiums
+		c.poles += season.poles
+		c.dnfs += season.dnfs
+		c.points += season.points
+		c.seasons = append(c.seasons, *season)
+	}
+	return c
+}
+
+func (c career) summary() string {
+	if len(c.seasons) == 0 {
+		return "No races found."
+	}
+	first, last := c.seasons[0].season, c.seasons[len(c.seasons)-1].season
+	return fmt.Sprintf("Seasons: %s–%s (%d)\nStarts: %d   Wins: %d   Podiums: %d   Poles: %d   Points: %s",
+		first, last, len(c.seasons), c.starts, c.wins, c.podiums, c.poles, formatPoints(c.points))
+}
+
+// bars — очки по сезонам, над столбцом место в чемпионате
+func (c career) bars() []chartBar {
+	bars := make([]chartBar, 0, len(c.seasons))
+	for _, s := range c.seasons {
+		caption := ""
+		if s.position != "" {
+			caption = "P" + s.position
+		}
+		teamID := ""
+		if len(s.teams) > 0 {
+			teamID = s.teams[0].ConstructorID
+		}
+		bars = append(bars, chartBar{label: s.season, value: s.points, caption: caption, color: teamColor(teamID)})
+	}
+	return bars
+}
+
+// dnfRate — доля сходов среди стартов
+func dnfRate(dnfs, starts int) string {
+	if starts == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.0f%%", 100*float64(dnfs)/float64(starts))
+}
+
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
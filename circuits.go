@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Режимы вкладки трасс
+const (
+	circuitsOfSeason = "This Season"
+	circuitsAllTime  = "All Time"
+)
+
+// circuitsPanel — вкладка "Circuits": трассы сезона или все трассы за историю.
+// Клик по строке открывает окно с историей трассы
+func (v *SeasonView) circuitsPanel() fyne.CanvasObject {
+	v.circuitsTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	v.circuitsTable.OnSelected = func(id widget.TableCellID) {
+		v.circuitsTable.UnselectAll() // выделение не нужно — строка работает как кнопка
+		if index := id.Row - 1; index >= 0 && index < len(v.circuits) {
+			v.app.openCircuitWindow(v.circuits[index])
+		}
+	}
+	v.circuitsMode = widget.NewRadioGroup([]string{circuitsOfSeason, circuitsAllTime}, func(string) { v.loadCircuits() })
+	v.circuitsMode.Horizontal = true
+	v.circuitsMode.Required = true
+	v.circuitsMode.SetSelected(circuitsOfSeason) // таблица уже создана: выбор сразу вызывает loadCircuits
+	return container.NewBorder(v.circuitsMode, nil, nil, nil, container.NewVScroll(v.circuitsTable))
+}
+
+// loadCircuits загружает трассы для выбранного режима
+func (v *SeasonView) loadCircuits() {
+	if v.cancelCircuitsLoad != nil {
+		v.cancelCircuitsLoad()
+	}
+	resetTable(v.circuitsTable)
+	v.numColsCircuits = 0
+	v.circuits = nil
+	if v.seasonCtx == nil {
+		return // сезон ещё не загружен
+	}
+	ctx, cancel := context.WithCancel(v.seasonCtx)
+	v.cancelCircuitsLoad = cancel
+
+	year := v.loadedSeason
+	if v.circuitsMode.Selected == circuitsAllTime {
+		year = ""
+	}
+	go func() {
+		circuits, err := v.app.client.Circuits(ctx, year)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Println("Error loading circuits:", err)
+				return
+			}
+			v.circuits = circuits
+			rows := make([][]string, 0, len(circuits))
+			for _, circuit := range circuits {
+				rows = append(rows, circuitRow(circuit))
+			}
+			v.numColsCircuits = showRows(v.circuitsTable, circuitHeaders, rows)
+			v.resizeTableColumnsEqually(v.circuitsTable, v.numColsCircuits)
+		})
+	}()
+}
+
+// openCircuitWindow показывает историю трассы: все гонки на ней, победителей и поулы
+func (a *App) openCircuitWindow(circuit ergast.Circuit) {
+	window := a.fyneApp.NewWindow("Circuit — " + circuit.CircuitName)
+	window.Resize(fyne.NewSize(900, 600))
+	ctx, cancel := context.WithCancel(context.Background())
+	window.SetOnClosed(cancel)
+
+	title := widget.NewLabelWithStyle(circuit.CircuitName, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	info := widget.NewLabel(fmt.Sprintf("Location: %s, %s\nCoordinates: %s, %s",
+		circuit.Location.Locality, circuit.Location.Country, circuit.Location.Lat, circuit.Location.Long))
+	link := widget.NewHyperlink("Wikipedia: "+circuit.CircuitName, parseURL(circuit.URL))
+	status := widget.NewLabel("Loading circuit history...")
+	table := widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	top := container.NewVBox(title, info, link, status)
+	window.SetContent(container.NewBorder(top, nil, nil, nil, container.NewVScroll(table)))
+	window.Show()
+
+	go func() {
+		races, err := a.client.CircuitRaces(ctx, circuit.CircuitID)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				status.SetText("Error loading circuit history: " + err.Error())
+				return
+			}
+			if len(races) == 0 {
+				status.SetText("No races found at this circuit.")
+				return
+			}
+			status.SetText(fmt.Sprintf("Races held: %d (first: %s, last: %s)", len(races), races[0].Season, races[len(races)-1].Season))
+			rows := make([][]string, 0, len(races))
+			for _, race := range races {
+				rows = append(rows, circuitRaceRow(race))
+			}
+			numCols := showRows(table, circuitRaceHeaders, rows)
+			poleCol := slices.Index(circuitRaceHeaders, "Pole")
+			a.linkProfiles(table, func(row, col int) (ergast.Driver, bool) { // победитель или поул — по колонке
+				switch race := races[row]; {
+				case col == poleCol && len(race.QualifyingResults) > 0:
+					return race.QualifyingResults[0].Driver, true
+				case col != poleCol && len(race.Results) > 0:
+					return race.Results[0].Driver, true
+				}
+				return ergast.Driver{}, false
+			}, rowConstructors(races, circuitRaceHeaders, func(race ergast.Race) ergast.Constructor {
+				if len(race.Results) == 0 {
+					return ergast.Constructor{}
+				}
+				return race.Results[0].Constructor
+			}))
+			setEqualColumnWidths(table, window.Canvas().Size().Width, numCols)
+		})
+	}()
+}
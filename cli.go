@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"F1_catalog/api"
+	"F1_catalog/ergast"
+)
+
+const cliUsage = `Usage: f1catalog <command> [arguments] [--format table|json|csv|markdown]
+
+Commands:
+  schedule <year>            race calendar of the season
+  results <year> <round>     race classification
+  sprint <year> <round>      sprint classification
+  drivers <year>             drivers of the season
+  constructors <year>        constructors of the season
+  ics <year> [round]         iCalendar (.ics) with the sessions of the season
+                             or of one round, e.g. ics 2024 > f1-2024.ics
+  sync [from] [to]           download seasons into the offline store
+  serve [--addr :8080]       serve the catalog as a JSON REST API and
+                             the offline store as an Ergast-compatible API
+
+Without a command the graphical catalog is opened.
+`
+
+// cliOutput — результат подкоманды: исходные структуры для JSON и строки для остальных форматов
+type cliOutput struct {
+	data    any
+	headers []string
+	rows    [][]string
+}
+
+// cliCommand получает клиент и позиционные аргументы подкоманды
+type cliCommand struct {
+	args int // сколько позиционных аргументов нужно
+	run  func(ctx context.Context, client ergast.Client, args []string) (*cliOutput, error)
+}
+
+var cliCommands = map[string]cliCommand{
+	"schedule": {args: 1, run: func(ctx context.Context, client ergast.Client, args []string) (*cliOutput, error) {
+		races, err := client.Season(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		out := &cliOutput{data: races, headers: raceHeaders}
+		for _, race := range races {
+			out.rows = append(out.rows, raceRow(race))
+		}
+		return out, nil
+	}},
+	"results": {args: 2, run: func(ctx context.Context, client ergast.Client, args []string) (*cliOutput, error) {
+		results, err := client.Results(ctx, args[0], args[1])
+		if err != nil {
+			return nil, err
+		}
+		out := &cliOutput{data: results, headers: raceResultHeaders}
+		for _, result := range results {
+			out.rows = append(out.rows, raceResultRow(result))
+		}
+		return out, nil
+	}},
+	"sprint": {args: 2, run: func(ctx context.Context, client ergast.Client, args []string) (*cliOutput, error) {
+		results, err := client.SprintResults(ctx, args[0], args[1])
+		if err != nil {
+			return nil, err
+		}
+		out := &cliOutput{data: results, headers: raceResultHeaders}
+		for _, result := range results {
+			out.rows = append(out.rows, raceResultRow(ergast.RaceResult(result)))
+		}
+		return out, nil
+	}},
+	"drivers": {args: 1, run: func(ctx context.Context, client ergast.Client, args []string) (*cliOutput, error) {
+		drivers, err := client.Drivers(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		out := &cliOutput{data: drivers, headers: driverHeaders}
+		for _, driver := range drivers {
+			out.rows = append(out.rows, driverRow(driver))
+		}
+		return out, nil
+	}},
+	"constructors": {args: 1, run: func(ctx context.Context, client ergast.Client, args []string) (*cliOutput, error) {
+		constructors, err := client.Constructors(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		out := &cliOutput{data: constructors, headers: constructorHeaders}
+		for _, constructor := range constructors {
+			out.rows = append(out.rows, constructorRow(constructor))
+		}
+		return out, nil
+	}},
+}
+
+// runCLI выполняет подкоманду из args (без имени программы)
+func runCLI(args []string, stdout io.Writer) error {
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		fmt.Fprint(stdout, cliUsage)
+		return nil
+	}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	format := flags.String("format", "table", "output format: table, json, csv or markdown")
+	offline := flags.Bool("offline", false, "read only the offline store")
+	addr := flags.String("addr", ":8080", "listen address for serve")
+	positional, err := parseInterspersed(flags, args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil // справку по флагам flag уже напечатал
+	}
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "table", "json", "csv", "markdown":
+	default:
+		return fmt.Errorf("unknown format %q: use table, json, csv or markdown", *format)
+	}
+
+	httpClient, store, fallbackClient := newClients()
+	fallbackClient.SetOffline(*offline)
+	ctx := context.Background()
+
+	switch name {
+	case "sync":
+		return runSync(ctx, httpClient, store, positional, stdout)
+	case "ics":
+		return runICS(ctx, fallbackClient, positional, stdout)
+	case "serve":
+		mux := http.NewServeMux()
+		mux.Handle("/api/f1/", ergast.NewStoreHandler(store)) // Ergast-совместимые пути для старых инструментов
+		mux.Handle("/", api.NewHandler(fallbackClient))
+		fmt.Fprintf(stdout, "Serving F1 catalog API on %s (Ergast-compatible data under /api/f1/)\n", *addr)
+		return http.ListenAndServe(*addr, mux)
+	}
+
+	command, ok := cliCommands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", name, cliUsage)
+	}
+	if len(positional) != command.args {
+		return fmt.Errorf("%s expects %d argument(s)\n\n%s", name, command.args, cliUsage)
+	}
+	out, err := command.run(ctx, fallbackClient, positional)
+	if err != nil {
+		return err
+	}
+	return writeOutput(stdout, *format, out)
+}
+
+// parseInterspersed разбирает флаги, стоящие и до, и после позиционных аргументов
+// (стандартный flag останавливается на первом позиционном)
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func runSync(ctx context.Context, src ergast.Client, store *ergast.Store, args []string, stdout io.Writer) error {
+	from, to := 1950, time.Now().Year()
+	var err error
+	if len(args) > 0 {
+		if from, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("invalid season %q", args[0])
+		}
+		to = from
+	}
+	if len(args) > 1 {
+		if to, err = strconv.Atoi(args[1]); err != nil {
+			return fmt.Errorf("invalid season %q", args[1])
+		}
+	}
+	err = ergast.Sync(ctx, src, store, from, to, func(year int) {
+		fmt.Fprintf(stdout, "Syncing season %d...\n", year)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Offline data synced for seasons %d-%d.\n", from, to)
+	return nil
+}
+
+// runICS печатает календарь сезона или одного этапа в формате iCalendar
+func runICS(ctx context.Context, client ergast.Client, args []string, stdout io.Writer) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("ics expects a year and an optional round\n\n%s", cliUsage)
+	}
+	races, err := client.Season(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if len(args) == 2 {
+		races = slices.DeleteFunc(races, func(race ergast.Race) bool { return race.Round != args[1] })
+		if len(races) == 0 {
+			return fmt.Errorf("no round %s in season %s", args[1], args[0])
+		}
+	}
+	return writeICS(stdout, args[0], races, time.Now())
+}
+
+func writeOutput(w io.Writer, format string, out *cliOutput) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(out.headers, "\t"))
+		for _, row := range out.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(out.data)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(out.headers)
+		cw.WriteAll(out.rows) // WriteAll сам делает Flush
+		return cw.Error()
+	case "markdown":
+		fmt.Fprintln(w, "| "+strings.Join(escapeMarkdown(out.headers), " | ")+" |")
+		fmt.Fprintln(w, "|"+strings.Repeat(" --- |", len(out.headers)))
+		for _, row := range out.rows {
+			fmt.Fprintln(w, "| "+strings.Join(escapeMarkdown(row), " | ")+" |")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func escapeMarkdown(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return escaped
+}
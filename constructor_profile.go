@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+var constructorSeasonHeaders = []string{"Season", "Driver", "Starts", "Wins", "Podiums", "Poles", "Points", "DNF Rate", "Championship"}
+
+// constructorSeasonRows — строка на каждого пилота сезона, чтобы по клику открывался его профиль.
+// Статистика сезона — в первой строке, в остальных только пилот
+func constructorSeasonRows(s careerSeason) ([][]string, []ergast.Driver) {
+	row := []string{
+		s.season,
+		"",
+		strconv.Itoa(s.starts),
+		strconv.Itoa(s.wins),
+		strconv.Itoa(s.podiums),
+		strconv.Itoa(s.poles),
+		formatPoints(s.points),
+		dnfRate(s.dnfs, s.starts),
+		s.position,
+	}
+	if len(s.drivers) == 0 {
+		return [][]string{row}, []ergast.Driver{{}}
+	}
+	driverCol := slices.Index(constructorSeasonHeaders, "Driver")
+	rows := make([][]string, 0, len(s.drivers))
+	for i, driver := range s.drivers {
+		if i > 0 {
+			row = make([]string, len(constructorSeasonHeaders))
+		}
+		row[driverCol] = driver.GivenName + " " + driver.FamilyName
+		rows = append(rows, row)
+	}
+	return rows, s.drivers
+}
+
+// constructorSummary — сводка истории команды: титулы и надёжность
+func constructorSummary(c career) string {
+	text := c.summary()
+	if len(c.titles) > 0 {
+		text += fmt.Sprintf("\nConstructors' championships: %d (%s)", len(c.titles), strings.Join(c.titles, ", "))
+	} else if c.bestFinish > 0 {
+		text += fmt.Sprintf("\nBest championship finish: %s (%s)", ordinal(c.bestFinish), strings.Join(c.bestFinishYears, ", "))
+	}
+	if c.starts > 0 {
+		text += fmt.Sprintf("\nReliability: %d retirements in %d starts (%s)", c.dnfs, c.starts, dnfRate(c.dnfs, c.starts))
+	}
+	return text
+}
+
+// openConstructorWindow открывает профиль команды: сезоны, составы, очки по годам и надёжность
+func (a *App) openConstructorWindow(constructor ergast.Constructor) {
+	window := a.fyneApp.NewWindow("Constructor — " + constructor.Name)
+	window.Resize(fyne.NewSize(1000, 700))
+	ctx, cancel := context.WithCancel(context.Background())
+	window.SetOnClosed(cancel)
+
+	title := widget.NewLabelWithStyle(constructor.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	bio := widget.NewLabel("Nationality: " + constructor.Nationality)
+	link := widget.NewHyperlink("Wikipedia: "+constructor.Name, parseURL(constructor.URL))
+	summary := widget.NewLabel("Loading constructor history...")
+	chart := newBarChart()
+	table := widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	split := container.NewVSplit(container.NewHScroll(chart), container.NewVScroll(table)) // у долгих карьер столбцы шире окна — листаем
+	split.SetOffset(0.4)
+	window.SetContent(container.NewBorder(container.NewVBox(title, bio, link, summary), nil, nil, nil, split))
+	window.Show()
+
+	go func() {
+		races, err := a.client.ConstructorResults(ctx, constructor.ConstructorID)
+		var lists []ergast.StandingsList
+		if err == nil {
+			lists, err = a.client.ConstructorSeasonStandings(ctx, constructor.ConstructorID)
+		}
+		var poles []ergast.Race
+		if err == nil {
+			poles = a.loadPoles(ctx)
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				summary.SetText("Error loading constructor history: " + err.Error())
+				return
+			}
+			standings := make(map[string]championshipResult, len(lists))
+			for _, list := range lists {
+				if len(list.ConstructorStandings) > 0 {
+					s := list.ConstructorStandings[0]
+					standings[list.Season] = championshipResult{position: s.Position, positionText: s.PositionText, points: s.Points}
+				}
+			}
+			c := newCareer(races, poles, standings)
+
+			summary.SetText(constructorSummary(c))
+			chart.SetBars(c.bars())
+			var rows [][]string
+			var drivers []ergast.Driver // пилот каждой строки
+			for _, season := range c.seasons {
+				seasonRows, seasonDrivers := constructorSeasonRows(season)
+				rows = append(rows, seasonRows...)
+				drivers = append(drivers, seasonDrivers...)
+			}
+			numCols := showRows(table, constructorSeasonHeaders, rows)
+			driverCol := slices.Index(constructorSeasonHeaders, "Driver")
+			a.linkProfiles(table, func(row, col int) (ergast.Driver, bool) {
+				if col != driverCol || row < 0 || row >= len(drivers) {
+					return ergast.Driver{}, false
+				}
+				return drivers[row], true
+			}, nil)
+			setEqualColumnWidths(table, window.Canvas().Size().Width, numCols)
+		})
+	}()
+}
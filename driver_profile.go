@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+var driverSeasonHeaders = []string{"Season", "Team", "Starts", "Wins", "Podiums", "Poles", "Points", "Championship"}
+
+func driverSeasonRow(s careerSeason) []string {
+	teams := make([]string, 0, len(s.teams))
+	for _, team := range s.teams {
+		teams = append(teams, team.Name)
+	}
+	return []string{
+		s.season,
+		strings.Join(teams, ", "),
+		strconv.Itoa(s.starts),
+		strconv.Itoa(s.wins),
+		strconv.Itoa(s.podiums),
+		strconv.Itoa(s.poles),
+		formatPoints(s.points),
+		s.position,
+	}
+}
+
+// driverSummary — сводка карьеры пилота с лучшим местом в чемпионате
+func driverSummary(c career) string {
+	text := c.summary()
+	if c.bestFinish > 0 {
+		text += fmt.Sprintf("\nBest championship finish: %s (%s)", ordinal(c.bestFinish), strings.Join(c.bestFinishYears, ", "))
+	}
+	return text
+}
+
+// openDriverWindow открывает профиль пилота: биография, сводка карьеры, сезоны и диаграмма очков
+func (a *App) openDriverWindow(driver ergast.Driver) {
+	name := driverName(driver)
+	if strings.TrimSpace(name) == "" {
+		name = driver.DriverID // известен только id (например, в таблице кругов)
+	}
+	window := a.fyneApp.NewWindow("Driver — " + name)
+	window.Resize(fyne.NewSize(1000, 700))
+	ctx, cancel := context.WithCancel(context.Background())
+	window.SetOnClosed(cancel)
+
+	title := widget.NewLabelWithStyle(name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	bio := widget.NewLabel("Loading driver profile...")
+	link := widget.NewHyperlink("", nil)
+	summary := widget.NewLabel("")
+	chart := newBarChart()
+	table := widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	split := container.NewVSplit(container.NewHScroll(chart), container.NewVScroll(table)) // у долгих карьер столбцы шире окна — листаем
+	split.SetOffset(0.4)
+	window.SetContent(container.NewBorder(container.NewVBox(title, bio, link, summary), nil, nil, nil, split))
+	window.Show()
+
+	go func() {
+		profile, err := a.client.Driver(ctx, driver.DriverID)
+		var races []ergast.Race
+		var lists []ergast.StandingsList
+		if err == nil {
+			races, err = a.client.DriverResults(ctx, driver.DriverID)
+		}
+		if err == nil {
+			lists, err = a.client.DriverSeasonStandings(ctx, driver.DriverID)
+		}
+		var poles []ergast.Race
+		if err == nil {
+			poles = a.loadPoles(ctx)
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				bio.SetText("Error loading driver profile: " + err.Error())
+				return
+			}
+			standings := make(map[string]championshipResult, len(lists))
+			for _, list := range lists {
+				if len(list.DriverStandings) > 0 {
+					s := list.DriverStandings[0]
+					standings[list.Season] = championshipResult{position: s.Position, positionText: s.PositionText, points: s.Points}
+				}
+			}
+			c := newCareer(races, poles, standings)
+
+			title.SetText(driverName(*profile))
+			bio.SetText(driverBio(*profile, c.lastRace))
+			link.SetText("Wikipedia: " + driverName(*profile))
+			link.SetURL(parseURL(profile.URL))
+			summary.SetText(driverSummary(c))
+			chart.SetBars(c.bars())
+			rows := make([][]string, 0, len(c.seasons))
+			for _, season := range c.seasons {
+				rows = append(rows, driverSeasonRow(season))
+			}
+			numCols := showRows(table, driverSeasonHeaders, rows)
+			a.linkProfiles(table, nil, rowConstructors(c.seasons, driverSeasonHeaders, func(s careerSeason) ergast.Constructor {
+				return s.teams[0] // у сезона всегда есть хотя бы одна команда
+			}))
+			setEqualColumnWidths(table, window.Canvas().Size().Width, numCols)
+		})
+	}()
+}
+
+// driverBio — строка биографии. У давно закончивших карьеру пилотов дополнительно
+// показываем возраст на момент последней гонки
+func driverBio(driver ergast.Driver, lastRace ergast.Race) string {
+	parts := []string{"Nationality: " + driver.Nationality}
+	if driver.DateOfBirth != "" {
+		parts = append(parts, "Born: "+driver.DateOfBirth)
+	}
+	if born, err := time.Parse("2006-01-02", driver.DateOfBirth); err == nil {
+		now := time.Now()
+		parts = append(parts, fmt.Sprintf("Age: %d", yearsBetween(born, now)))
+		if last, ok := lastRace.Start(); ok && last.Year() < now.Year()-1 {
+			parts = append(parts, fmt.Sprintf("Age at last race: %d", yearsBetween(born, last)))
+		}
+	}
+	if driver.PermanentNumber != "" {
+		parts = append(parts, "Number: "+driver.PermanentNumber)
+	}
+	if driver.Code != "" {
+		parts = append(parts, "Code: "+driver.Code)
+	}
+	return strings.Join(parts, "   ")
+}
+
+func yearsBetween(from, to time.Time) int {
+	years := to.Year() - from.Year()
+	// день рождения в этом году ещё не наступил; YearDay не годится — в високосный год дни сдвигаются
+	if to.Month() < from.Month() || (to.Month() == from.Month() && to.Day() < from.Day()) {
+		years--
+	}
+	return years
+}
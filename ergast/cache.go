@@ -0,0 +1,99 @@
+package ergast
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheSource — источник в SourceRecorder, когда ответ взят из дискового кэша
+const CacheSource = "disk cache"
+
+// Сроки жизни записей кэша
+const (
+	FinishedSeasonTTL  = 30 * 24 * time.Hour // прошедшие сезоны уже не меняются
+	CurrentScheduleTTL = 6 * time.Hour       // календарь и составы текущего сезона
+	CurrentResultsTTL  = 15 * time.Minute    // результаты и зачёт текущего сезона обновляются после каждой гонки
+	ReferenceTTL       = 24 * time.Hour      // запросы без сезона (список сезонов, карьера пилота и т.п.)
+)
+
+// Cache — дисковый кэш ответов API. Ключ — путь запроса вместе с limit/offset,
+// файлы одного сезона лежат в отдельной папке, чтобы их было легко сбросить
+type Cache struct {
+	Dir string
+	TTL func(key string) time.Duration
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{Dir: dir, TTL: DefaultTTL}
+}
+
+// Get возвращает сохранённый ответ, если он ещё не устарел
+func (c *Cache) Get(key string) ([]byte, bool) {
+	file := c.file(key)
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) > c.TTL(key) {
+		return nil, false
+	}
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+func (c *Cache) Put(key string, body []byte) error {
+	file := c.file(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	tmp := file + ".tmp" // пишем во временный файл, чтобы не оставить обрезанную запись
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// Invalidate удаляет все ответы сезона year
+func (c *Cache) Invalidate(year string) error {
+	return os.RemoveAll(filepath.Join(c.Dir, year))
+}
+
+// file превращает "/2023/5/results.json?limit=100&offset=0" в <Dir>/2023/5_results.json_limit=100_offset=0
+func (c *Cache) file(key string) string {
+	segment, rest := splitKey(key)
+	name := strings.NewReplacer("/", "_", "?", "_", "&", "_").Replace(strings.TrimPrefix(rest, "/"))
+	if name == "" || strings.HasPrefix(name, ".") {
+		name = "index" + name // ответ на сам "/2023.json"
+	}
+	return filepath.Join(c.Dir, segment, name)
+}
+
+// DefaultTTL выбирает срок жизни по сезону и виду данных
+func DefaultTTL(key string) time.Duration {
+	segment, rest := splitKey(key)
+	year, err := strconv.Atoi(segment)
+	if segment != "current" && err != nil {
+		return ReferenceTTL
+	}
+	if segment != "current" && year < time.Now().Year() {
+		return FinishedSeasonTTL
+	}
+	for _, kind := range []string{"results", "qualifying", "sprint", "Standings", "laps", "pitstops"} {
+		if strings.Contains(rest, kind) {
+			return CurrentResultsTTL
+		}
+	}
+	return CurrentScheduleTTL
+}
+
+// splitKey отделяет первый сегмент пути (обычно сезон) от остальной части
+func splitKey(key string) (segment, rest string) {
+	key = strings.TrimPrefix(key, "/")
+	if i := strings.IndexAny(key, "/."); i >= 0 {
+		return key[:i], key[i:]
+	}
+	return key, ""
+}
@@ -0,0 +1,105 @@
+package ergast
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDefaultTTL(t *testing.T) {
+	thisYear := strconv.Itoa(time.Now().Year())
+	tests := []struct {
+		key  string
+		want time.Duration
+	}{
+		{"/2008.json?limit=100&offset=0", FinishedSeasonTTL},
+		{"/2008/5/results.json?limit=100&offset=0", FinishedSeasonTTL},
+		{"/" + thisYear + ".json?limit=100&offset=0", CurrentScheduleTTL},
+		{"/" + thisYear + "/drivers.json?limit=100&offset=0", CurrentScheduleTTL},
+		{"/" + thisYear + "/5/results.json?limit=100&offset=0", CurrentResultsTTL},
+		{"/" + thisYear + "/5/qualifying.json?limit=100&offset=0", CurrentResultsTTL},
+		{"/" + thisYear + "/driverStandings.json?limit=100&offset=0", CurrentResultsTTL},
+		{"/" + thisYear + "/5/laps.json?limit=100&offset=0", CurrentResultsTTL},
+		{"/current/next.json?limit=100&offset=0", CurrentScheduleTTL},
+		{"/current/last/results.json?limit=100&offset=0", CurrentResultsTTL},
+		{"/seasons.json?limit=100&offset=0", ReferenceTTL},
+		{"/drivers/alonso/results.json?limit=100&offset=0", ReferenceTTL},
+		{"/qualifying/1.json?limit=100&offset=0", ReferenceTTL},
+	}
+	for _, tt := range tests {
+		if got := DefaultTTL(tt.key); got != tt.want {
+			t.Errorf("DefaultTTL(%q) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestCacheFile(t *testing.T) {
+	cache := NewCache("cache")
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"/2023/5/results.json?limit=100&offset=0", filepath.Join("cache", "2023", "5_results.json_limit=100_offset=0")},
+		{"/2023.json?limit=100&offset=0", filepath.Join("cache", "2023", "index.json_limit=100_offset=0")},
+		{"/drivers/alonso.json?limit=100&offset=0", filepath.Join("cache", "drivers", "alonso.json_limit=100_offset=0")},
+	}
+	for _, tt := range tests {
+		if got := cache.file(tt.key); got != tt.want {
+			t.Errorf("file(%q) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	const key = "/2023/5/results.json?limit=100&offset=0"
+	tests := []struct {
+		name   string
+		ttl    time.Duration
+		age    time.Duration
+		wantOK bool
+	}{
+		{"fresh", time.Hour, time.Minute, true},
+		{"expired", time.Hour, 2 * time.Hour, false},
+		{"zero ttl", 0, time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &Cache{Dir: t.TempDir(), TTL: func(string) time.Duration { return tt.ttl }}
+			if err := cache.Put(key, []byte("body")); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			modified := time.Now().Add(-tt.age)
+			if err := os.Chtimes(cache.file(key), modified, modified); err != nil {
+				t.Fatal(err)
+			}
+			body, ok := cache.Get(key)
+			if ok != tt.wantOK {
+				t.Fatalf("Get ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && string(body) != "body" {
+				t.Errorf("Get = %q, want %q", body, "body")
+			}
+		})
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	cache := NewCache(t.TempDir())
+	keys := []string{"/2023/5/results.json?limit=100&offset=0", "/2022/5/results.json?limit=100&offset=0"}
+	for _, key := range keys {
+		if err := cache.Put(key, []byte("body")); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if err := cache.Invalidate("2023"); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+	if _, ok := cache.Get(keys[0]); ok {
+		t.Error("2023 is still cached after Invalidate")
+	}
+	if _, ok := cache.Get(keys[1]); !ok {
+		t.Error("Invalidate(2023) removed 2022")
+	}
+}
@@ -0,0 +1,567 @@
+package ergast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL — Jolpica, Ergast-совместимая замена закрытого ergast.com.
+// Всегда опрашивается первым, зеркала идут следом
+const DefaultBaseURL = "https://api.jolpi.ca/ergast/f1"
+
+// pageSize — сколько записей просить за один запрос (больше 100 Jolpica не отдаёт)
+const pageSize = 100
+
+// DefaultRequestInterval — пауза между запросами в сеть: Jolpica пропускает не больше 4 запросов в секунду
+const DefaultRequestInterval = 250 * time.Millisecond
+
+// Повторы ответов 429 Too Many Requests
+const (
+	maxRetries   = 4
+	maxRetryWait = time.Minute // дольше внутри запроса не ждём: ошибка уходит вызывающему, Sync подождёт сам
+)
+
+// Client — источник данных каталога. UI работает только с этим интерфейсом,
+// поэтому реализацию можно подменить (зеркало, локальная копия, httptest)
+type Client interface {
+	Seasons(ctx context.Context) ([]Season, error) // все сезоны, о которых знает источник, по возрастанию, включая опубликованный календарь следующего
+	Season(ctx context.Context, year string) ([]Race, error)
+	Results(ctx context.Context, year, round string) ([]RaceResult, error)
+	Drivers(ctx context.Context, year string) ([]Driver, error)
+	Constructors(ctx context.Context, year string) ([]Constructor, error)
+	Driver(ctx context.Context, driverID string) (*Driver, error)
+	DriverResults(ctx context.Context, driverID string) ([]Race, error)                  // все гонки пилота, в Results — только его строка
+	DriverSeasonStandings(ctx context.Context, driverID string) ([]StandingsList, error) // итог каждого сезона пилота
+	ConstructorResults(ctx context.Context, constructorID string) ([]Race, error)        // все гонки команды, в Results — её машины
+	ConstructorSeasonStandings(ctx context.Context, constructorID string) ([]StandingsList, error)
+	Circuits(ctx context.Context, year string) ([]Circuit, error) // year == "" — все трассы за историю
+	// CircuitRaces — все гонки на трассе; в Results только победитель, в QualifyingResults — поул
+	CircuitRaces(ctx context.Context, circuitID string) ([]Race, error)
+	// Poles — все гонки, по которым есть данные квалификаций; в QualifyingResults — только поул
+	Poles(ctx context.Context) ([]Race, error)
+	Qualifying(ctx context.Context, year, round string) ([]QualifyingResult, error)
+	SprintResults(ctx context.Context, year, round string) ([]SprintResult, error)     // пусто, если на этапе не было спринта
+	Laps(ctx context.Context, year, round string) ([]Lap, error)                       // Ergast хранит круги с 1996 года
+	PitStops(ctx context.Context, year, round string) ([]PitStop, error)               // и пит-стопы с 2011
+	DriverStandings(ctx context.Context, year, round string) ([]DriverStanding, error) // round == "" — положение на конец сезона
+	ConstructorStandings(ctx context.Context, year, round string) ([]ConstructorStanding, error)
+}
+
+// Refresher — клиент, который умеет сбрасывать сохранённые данные сезона
+type Refresher interface {
+	Refresh(year string) error
+}
+
+// ErrNotFound — запрошенного объекта (пилота, команды) нет в данных
+var ErrNotFound = errors.New("not found")
+
+// StatusError — сервер ответил кодом, отличным от 200
+type StatusError struct {
+	Code          int
+	RetryAfter    time.Duration // из заголовка Retry-After; 0 — повторять сразу
+	HasRetryAfter bool          // заголовок Retry-After есть и разобран
+}
+
+func (e *StatusError) Error() string {
+	if e.HasRetryAfter {
+		return fmt.Sprintf("server error: %d (retry after %s)", e.Code, e.RetryAfter)
+	}
+	return fmt.Sprintf("server error: %d", e.Code)
+}
+
+// retryDelay — сколько ждать перед повтором: Retry-After, а без него — backoff
+func (e *StatusError) retryDelay(backoff time.Duration) time.Duration {
+	if e.HasRetryAfter {
+		return e.RetryAfter
+	}
+	return backoff
+}
+
+// tooManyRequests — сервер ограничил частоту запросов; возвращает, сколько подождать
+func tooManyRequests(err error) (*StatusError, bool) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Code == http.StatusTooManyRequests {
+		return statusErr, true
+	}
+	return nil, false
+}
+
+// HTTPClient ходит в Ergast-совместимые API по HTTP. Адреса опрашиваются
+// по порядку: если один вернул ошибку или не 200, запрос уходит на следующий
+type HTTPClient struct {
+	BaseURLs        []string
+	HTTP            *http.Client
+	Cache           *Cache        // nil — без кэша
+	RequestInterval time.Duration // минимальная пауза между запросами в сеть, 0 — без ограничения
+
+	mu          sync.Mutex
+	nextRequest time.Time // раньше этого момента следующий запрос не уходит
+}
+
+// NewHTTPClient создаёт клиент с Jolpica на первом месте и зеркалами после неё
+func NewHTTPClient(mirrors ...string) *HTTPClient {
+	baseURLs := []string{DefaultBaseURL}
+	for _, mirror := range mirrors {
+		mirror = strings.TrimSuffix(strings.TrimSpace(mirror), "/")
+		if mirror != "" && mirror != DefaultBaseURL {
+			baseURLs = append(baseURLs, mirror)
+		}
+	}
+	return &HTTPClient{
+		BaseURLs:        baseURLs,
+		HTTP:            http.DefaultClient,
+		RequestInterval: DefaultRequestInterval,
+	}
+}
+
+// Refresh сбрасывает закэшированные ответы сезона, чтобы следующая загрузка пошла в сеть
+func (c *HTTPClient) Refresh(year string) error {
+	if c.Cache == nil {
+		return nil
+	}
+	return c.Cache.Invalidate(year)
+}
+
+func (c *HTTPClient) Seasons(ctx context.Context) ([]Season, error) {
+	var seasons []Season
+	err := c.getAll(ctx, "/seasons", func(data *MRData) {
+		if data.SeasonTable != nil {
+			seasons = append(seasons, data.SeasonTable.Seasons...)
+		}
+	})
+	return seasons, err
+}
+
+func (c *HTTPClient) Season(ctx context.Context, year string) ([]Race, error) {
+	var races []Race
+	err := c.getAll(ctx, "/"+year, func(data *MRData) {
+		if data.RaceTable != nil {
+			races = append(races, data.RaceTable.Races...)
+		}
+	})
+	return races, err
+}
+
+func (c *HTTPClient) Results(ctx context.Context, year, round string) ([]RaceResult, error) {
+	var results []RaceResult
+	err := c.getAll(ctx, fmt.Sprintf("/%s/%s/results", year, round), func(data *MRData) {
+		if data.RaceTable == nil {
+			return // гонка ещё не проведена
+		}
+		for _, race := range data.RaceTable.Races { // на каждой странице — та же гонка с частью результатов
+			results = append(results, race.Results...)
+		}
+	})
+	return results, err
+}
+
+func (c *HTTPClient) Drivers(ctx context.Context, year string) ([]Driver, error) {
+	var drivers []Driver
+	err := c.getAll(ctx, fmt.Sprintf("/%s/drivers", year), func(data *MRData) {
+		if data.DriverTable != nil {
+			drivers = append(drivers, data.DriverTable.Drivers...)
+		}
+	})
+	return drivers, err
+}
+
+func (c *HTTPClient) Constructors(ctx context.Context, year string) ([]Constructor, error) {
+	var constructors []Constructor
+	err := c.getAll(ctx, fmt.Sprintf("/%s/constructors", year), func(data *MRData) {
+		if data.ConstructorTable != nil {
+			constructors = append(constructors, data.ConstructorTable.Constructors...)
+		}
+	})
+	return constructors, err
+}
+
+func (c *HTTPClient) Driver(ctx context.Context, driverID string) (*Driver, error) {
+	var drivers []Driver
+	err := c.getAll(ctx, "/drivers/"+url.PathEscape(driverID), func(data *MRData) {
+		if data.DriverTable != nil {
+			drivers = append(drivers, data.DriverTable.Drivers...)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(drivers) == 0 {
+		return nil, ErrNotFound
+	}
+	return &drivers[0], nil
+}
+
+func (c *HTTPClient) DriverResults(ctx context.Context, driverID string) ([]Race, error) {
+	var races []Race
+	err := c.getAll(ctx, "/drivers/"+url.PathEscape(driverID)+"/results", func(data *MRData) {
+		if data.RaceTable != nil {
+			races = append(races, data.RaceTable.Races...)
+		}
+	})
+	return races, err
+}
+
+func (c *HTTPClient) DriverSeasonStandings(ctx context.Context, driverID string) ([]StandingsList, error) {
+	var lists []StandingsList
+	err := c.getAll(ctx, "/drivers/"+url.PathEscape(driverID)+"/driverStandings", func(data *MRData) {
+		if data.StandingsTable != nil {
+			lists = append(lists, data.StandingsTable.StandingsLists...)
+		}
+	})
+	return lists, err
+}
+
+// ConstructorResults собирает гонки команды. Строк по две на гонку, поэтому
+// гонка может прийти частями на соседних страницах — склеиваем, как в Laps
+func (c *HTTPClient) ConstructorResults(ctx context.Context, constructorID string) ([]Race, error) {
+	var races []Race
+	err := c.getAll(ctx, "/constructors/"+url.PathEscape(constructorID)+"/results", func(data *MRData) {
+		if data.RaceTable == nil {
+			return
+		}
+		for _, race := range data.RaceTable.Races {
+			if last := len(races) - 1; last >= 0 && races[last].Season == race.Season && races[last].Round == race.Round {
+				races[last].Results = append(races[last].Results, race.Results...)
+				continue
+			}
+			races = append(races, race)
+		}
+	})
+	return races, err
+}
+
+func (c *HTTPClient) ConstructorSeasonStandings(ctx context.Context, constructorID string) ([]StandingsList, error) {
+	var lists []StandingsList
+	err := c.getAll(ctx, "/constructors/"+url.PathEscape(constructorID)+"/constructorStandings", func(data *MRData) {
+		if data.StandingsTable != nil {
+			lists = append(lists, data.StandingsTable.StandingsLists...)
+		}
+	})
+	return lists, err
+}
+
+func (c *HTTPClient) Circuits(ctx context.Context, year string) ([]Circuit, error) {
+	path := "/circuits"
+	if year != "" {
+		path = "/" + year + "/circuits"
+	}
+	var circuits []Circuit
+	err := c.getAll(ctx, path, func(data *MRData) {
+		if data.CircuitTable != nil {
+			circuits = append(circuits, data.CircuitTable.Circuits...)
+		}
+	})
+	return circuits, err
+}
+
+// CircuitRaces собирает расписание гонок на трассе и дополняет его победителями
+// (/results/1) и поулами (/qualifying/1, данные квалификаций есть не за все годы)
+func (c *HTTPClient) CircuitRaces(ctx context.Context, circuitID string) ([]Race, error) {
+	var races []Race
+	err := c.getAll(ctx, "/circuits/"+url.PathEscape(circuitID)+"/races", func(data *MRData) {
+		if data.RaceTable != nil {
+			races = append(races, data.RaceTable.Races...)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(races)) // "сезон/этап" -> гонка
+	for i, race := range races {
+		index[race.Season+"/"+race.Round] = i
+	}
+	err = c.getAll(ctx, "/circuits/"+url.PathEscape(circuitID)+"/results/1", func(data *MRData) {
+		if data.RaceTable == nil {
+			return
+		}
+		for _, race := range data.RaceTable.Races {
+			if i, ok := index[race.Season+"/"+race.Round]; ok {
+				races[i].Results = race.Results
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = c.getAll(ctx, "/circuits/"+url.PathEscape(circuitID)+"/qualifying/1", func(data *MRData) {
+		if data.RaceTable == nil {
+			return
+		}
+		for _, race := range data.RaceTable.Races {
+			if i, ok := index[race.Season+"/"+race.Round]; ok {
+				races[i].QualifyingResults = race.QualifyingResults
+			}
+		}
+	})
+	return races, err
+}
+
+func (c *HTTPClient) Poles(ctx context.Context) ([]Race, error) {
+	var races []Race
+	err := c.getAll(ctx, "/qualifying/1", func(data *MRData) {
+		if data.RaceTable != nil {
+			races = append(races, data.RaceTable.Races...)
+		}
+	})
+	return races, err
+}
+
+func (c *HTTPClient) Qualifying(ctx context.Context, year, round string) ([]QualifyingResult, error) {
+	var results []QualifyingResult
+	err := c.getAll(ctx, fmt.Sprintf("/%s/%s/qualifying", year, round), func(data *MRData) {
+		if data.RaceTable == nil {
+			return
+		}
+		for _, race := range data.RaceTable.Races {
+			results = append(results, race.QualifyingResults...)
+		}
+	})
+	return results, err
+}
+
+func (c *HTTPClient) SprintResults(ctx context.Context, year, round string) ([]SprintResult, error) {
+	var results []SprintResult
+	err := c.getAll(ctx, fmt.Sprintf("/%s/%s/sprint", year, round), func(data *MRData) {
+		if data.RaceTable == nil {
+			return
+		}
+		for _, race := range data.RaceTable.Races {
+			results = append(results, race.SprintResults...)
+		}
+	})
+	return results, err
+}
+
+// Laps собирает круги гонки. Страницы считаются по строкам Timings,
+// поэтому один круг может прийти частями на двух страницах — их склеиваем
+func (c *HTTPClient) Laps(ctx context.Context, year, round string) ([]Lap, error) {
+	var laps []Lap
+	err := c.getAll(ctx, fmt.Sprintf("/%s/%s/laps", year, round), func(data *MRData) {
+		if data.RaceTable == nil {
+			return
+		}
+		for _, race := range data.RaceTable.Races {
+			for _, lap := range race.Laps {
+				if last := len(laps) - 1; last >= 0 && laps[last].Number == lap.Number {
+					laps[last].Timings = append(laps[last].Timings, lap.Timings...)
+					continue
+				}
+				laps = append(laps, lap)
+			}
+		}
+	})
+	return laps, err
+}
+
+func (c *HTTPClient) PitStops(ctx context.Context, year, round string) ([]PitStop, error) {
+	var stops []PitStop
+	err := c.getAll(ctx, fmt.Sprintf("/%s/%s/pitstops", year, round), func(data *MRData) {
+		if data.RaceTable == nil {
+			return
+		}
+		for _, race := range data.RaceTable.Races {
+			stops = append(stops, race.PitStops...)
+		}
+	})
+	return stops, err
+}
+
+func (c *HTTPClient) DriverStandings(ctx context.Context, year, round string) ([]DriverStanding, error) {
+	var standings []DriverStanding
+	err := c.getAll(ctx, standingsPath(year, round, "driverStandings"), func(data *MRData) {
+		if data.StandingsTable == nil {
+			return
+		}
+		for _, list := range data.StandingsTable.StandingsLists {
+			standings = append(standings, list.DriverStandings...)
+		}
+	})
+	return standings, err
+}
+
+func (c *HTTPClient) ConstructorStandings(ctx context.Context, year, round string) ([]ConstructorStanding, error) {
+	var standings []ConstructorStanding
+	err := c.getAll(ctx, standingsPath(year, round, "constructorStandings"), func(data *MRData) {
+		if data.StandingsTable == nil {
+			return // до 1958 года Кубка конструкторов не было
+		}
+		for _, list := range data.StandingsTable.StandingsLists {
+			standings = append(standings, list.ConstructorStandings...)
+		}
+	})
+	return standings, err
+}
+
+func standingsPath(year, round, kind string) string {
+	if round == "" {
+		return fmt.Sprintf("/%s/%s", year, kind)
+	}
+	return fmt.Sprintf("/%s/%s/%s", year, round, kind)
+}
+
+// getAll проходит по всем страницам ответа (limit/offset/total) и отдаёт каждую в collect
+func (c *HTTPClient) getAll(ctx context.Context, path string, collect func(data *MRData)) error {
+	offset := 0
+	for {
+		data, err := c.get(ctx, path, offset)
+		if err != nil {
+			return err
+		}
+		collect(data)
+
+		limit, _ := strconv.Atoi(data.Limit) // сервер может урезать limit, поэтому берём его значение
+		total, _ := strconv.Atoi(data.Total)
+		offset += limit
+		if limit <= 0 || offset >= total {
+			return nil
+		}
+	}
+}
+
+// get запрашивает одну страницу path (без расширения): сначала из кэша,
+// затем у первого ответившего источника
+func (c *HTTPClient) get(ctx context.Context, path string, offset int) (*MRData, error) {
+	key := fmt.Sprintf("%s.json?limit=%d&offset=%d", path, pageSize, offset)
+	if c.Cache != nil {
+		if body, ok := c.Cache.Get(key); ok {
+			if data, err := decode(body); err == nil {
+				recordSource(ctx, CacheSource, false)
+				return data, nil
+			}
+		}
+	}
+
+	var lastErr error
+	for _, baseURL := range c.BaseURLs {
+		body, err := c.fetchWithRetry(ctx, baseURL+key)
+		if ctx.Err() != nil {
+			return nil, ctx.Err() // загрузку отменили — зеркала уже не нужны
+		}
+		if err != nil {
+			lastErr = err
+			continue // пробуем следующее зеркало
+		}
+		data, err := decode(body)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if c.Cache != nil {
+			c.Cache.Put(key, body) // кэш — не критичная часть, ошибку записи игнорируем
+		}
+		recordSource(ctx, baseURL, false)
+		return data, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no data sources configured")
+	}
+	return nil, lastErr
+}
+
+// fetchWithRetry — fetch с паузой RequestInterval перед каждым запросом. На 429 запрос
+// повторяется через Retry-After, а если заголовка нет — через 1, 2, 4... секунды
+func (c *HTTPClient) fetchWithRetry(ctx context.Context, url string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return nil, err
+		}
+		body, err := c.fetch(ctx, url)
+		statusErr, limited := tooManyRequests(err)
+		if !limited || attempt == maxRetries {
+			return body, err
+		}
+		delay := statusErr.retryDelay(time.Second << attempt)
+		if delay > maxRetryWait {
+			return nil, err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait выдерживает RequestInterval между запросами всех горутин, которые делят клиент
+func (c *HTTPClient) wait(ctx context.Context) error {
+	if c.RequestInterval <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	now := time.Now()
+	at := c.nextRequest
+	if at.Before(now) {
+		at = now
+	}
+	c.nextRequest = at.Add(c.RequestInterval)
+	c.mu.Unlock()
+	return sleep(ctx, at.Sub(now))
+}
+
+// sleep ждёт d или отмены ctx
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryAfter разбирает заголовок Retry-After: число секунд или HTTP-дата.
+// ok == false, если заголовка нет или он не разбирается
+func retryAfter(header string, now time.Time) (delay time.Duration, ok bool) {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(at.Sub(now), 0), true // дата уже прошла — повторяем сразу
+	}
+	return 0, false
+}
+
+// fetch скачивает тело ответа по полному адресу
+func (c *HTTPClient) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching data: %w", err)
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		statusErr := &StatusError{Code: resp.StatusCode}
+		statusErr.RetryAfter, statusErr.HasRetryAfter = retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return nil, statusErr
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return body, nil
+}
+
+// decode раскладывает ответ по структурам
+func decode(body []byte) (*MRData, error) {
+	var apiResponse Response
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+	return &apiResponse.MRData, nil
+}
@@ -0,0 +1,275 @@
+package ergast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// driversServer отдаёт total пилотов постранично, урезая limit до maxLimit, как Jolpica
+func driversServer(t *testing.T, total, maxLimit int, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit = min(limit, maxLimit)
+		var drivers []Driver
+		for i := offset; i < min(offset+limit, total); i++ {
+			drivers = append(drivers, Driver{DriverID: fmt.Sprintf("driver%d", i)})
+		}
+		json.NewEncoder(w).Encode(Response{MRData: MRData{
+			Limit:       strconv.Itoa(limit),
+			Offset:      strconv.Itoa(offset),
+			Total:       strconv.Itoa(total),
+			DriverTable: &DriverTable{Season: "2023", Drivers: drivers},
+		}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPClientPagination(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		maxLimit     int
+		wantRequests int32
+	}{
+		{"empty", 0, 100, 1},
+		{"one page", 20, 100, 1},
+		{"exact page", 100, 100, 1},
+		{"several pages", 250, 100, 3},
+		{"server cuts limit", 250, 30, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := driversServer(t, tt.total, tt.maxLimit, &requests)
+			client := &HTTPClient{BaseURLs: []string{server.URL}, HTTP: server.Client()}
+
+			drivers, err := client.Drivers(context.Background(), "2023")
+			if err != nil {
+				t.Fatalf("Drivers: %v", err)
+			}
+			if len(drivers) != tt.total {
+				t.Errorf("got %d drivers, want %d", len(drivers), tt.total)
+			}
+			for i, driver := range drivers {
+				if want := fmt.Sprintf("driver%d", i); driver.DriverID != want {
+					t.Fatalf("drivers[%d] = %s, want %s", i, driver.DriverID, want)
+				}
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestHTTPClientMirrorFailover(t *testing.T) {
+	var requests atomic.Int32
+	good := driversServer(t, 3, 100, &requests)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	t.Cleanup(failing.Close)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html>not json</html>")
+	}))
+	t.Cleanup(broken.Close)
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close() // соединение не установится
+
+	tests := []struct {
+		name       string
+		baseURLs   []string
+		wantSource string
+		wantErr    bool
+	}{
+		{"first answers", []string{good.URL, failing.URL}, good.URL, false},
+		{"server error", []string{failing.URL, good.URL}, good.URL, false},
+		{"invalid json", []string{broken.URL, good.URL}, good.URL, false},
+		{"connection refused", []string{closed.URL, good.URL}, good.URL, false},
+		{"all fail", []string{failing.URL, broken.URL}, "", true},
+		{"no sources", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &HTTPClient{BaseURLs: tt.baseURLs, HTTP: http.DefaultClient}
+			ctx, source := WithSourceRecorder(context.Background())
+
+			drivers, err := client.Drivers(ctx, "2023")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("want error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Drivers: %v", err)
+			}
+			if len(drivers) != 3 {
+				t.Errorf("got %d drivers, want 3", len(drivers))
+			}
+			if source.Source() != tt.wantSource {
+				t.Errorf("source = %q, want %q", source.Source(), tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestHTTPClientStatusError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	client := &HTTPClient{BaseURLs: []string{server.URL}, HTTP: server.Client()}
+
+	_, err := client.Season(context.Background(), "1800")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusNotFound {
+		t.Fatalf("err = %v, want StatusError 404", err)
+	}
+}
+
+func TestHTTPClientRetriesTooManyRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		limited    int32  // сколько первых ответов — 429
+		retryAfter string // заголовок Retry-After у 429
+		wantErr    bool
+		wantWait   time.Duration // примерная суммарная пауза
+	}{
+		{"no limit", 0, "", false, 0},
+		{"retry now", 2, "0", false, 0},
+		{"retry after seconds", 1, "1", false, time.Second},
+		{"no header backs off", 1, "", false, time.Second},
+		{"too long to wait", 1, "3600", true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.limited {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				json.NewEncoder(w).Encode(Response{MRData: MRData{Limit: "100", Total: "0", RaceTable: &RaceTable{}}})
+			}))
+			t.Cleanup(server.Close)
+			client := &HTTPClient{BaseURLs: []string{server.URL}, HTTP: server.Client()}
+
+			start := time.Now()
+			_, err := client.Season(context.Background(), "2023")
+			elapsed := time.Since(start)
+			if tt.wantErr {
+				if _, limited := tooManyRequests(err); !limited {
+					t.Fatalf("err = %v, want 429", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Season: %v", err)
+			}
+			if got, want := requests.Load(), tt.limited+1; got != want {
+				t.Errorf("made %d requests, want %d", got, want)
+			}
+			if elapsed < tt.wantWait || elapsed > tt.wantWait+500*time.Millisecond {
+				t.Errorf("took %s, want about %s", elapsed, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestHTTPClientRequestInterval(t *testing.T) {
+	var requests atomic.Int32
+	server := driversServer(t, 250, 100, &requests)
+	client := &HTTPClient{BaseURLs: []string{server.URL}, HTTP: server.Client(), RequestInterval: 50 * time.Millisecond}
+
+	start := time.Now()
+	if _, err := client.Drivers(context.Background(), "2023"); err != nil {
+		t.Fatalf("Drivers: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond { // три запроса — две паузы
+		t.Errorf("3 requests took %s, want at least 100ms", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"30", 30 * time.Second, true},
+		{"-5", 0, false},
+		{"Fri, 01 Mar 2024 12:02:00 GMT", 2 * time.Minute, true},
+		{"Fri, 01 Mar 2024 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := retryAfter(tt.header, now); got != tt.want || ok != tt.wantOK {
+			t.Errorf("retryAfter(%q) = %s, %v, want %s, %v", tt.header, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestHTTPClientCache(t *testing.T) {
+	var requests atomic.Int32
+	server := driversServer(t, 5, 100, &requests)
+	client := &HTTPClient{BaseURLs: []string{server.URL}, HTTP: server.Client(), Cache: NewCache(t.TempDir())}
+
+	for i := 0; i < 2; i++ {
+		ctx, source := WithSourceRecorder(context.Background())
+		if _, err := client.Drivers(ctx, "2000"); err != nil {
+			t.Fatalf("Drivers: %v", err)
+		}
+		want := server.URL
+		if i > 0 {
+			want = CacheSource
+		}
+		if source.Source() != want {
+			t.Errorf("call %d: source = %q, want %q", i, source.Source(), want)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("made %d requests, want 1 (second from cache)", got)
+	}
+
+	if err := client.Refresh("2000"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if _, err := client.Drivers(context.Background(), "2000"); err != nil {
+		t.Fatalf("Drivers: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("made %d requests after Refresh, want 2", got)
+	}
+}
+
+func TestHTTPClientEscapesIDs(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		json.NewEncoder(w).Encode(Response{MRData: MRData{Limit: "100", Total: "0", DriverTable: &DriverTable{}}})
+	}))
+	t.Cleanup(server.Close)
+	client := &HTTPClient{BaseURLs: []string{server.URL}, HTTP: server.Client()}
+
+	if _, err := client.Driver(context.Background(), "../2023?x"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	if want := "/drivers/..%2F2023%3Fx.json"; path != want {
+		t.Errorf("requested %s, want %s", path, want)
+	}
+}
@@ -0,0 +1,136 @@
+package ergast
+
+import (
+	"context"
+	"sync"
+)
+
+// FallbackClient берёт данные из Online, а если сеть недоступна или
+// включён офлайн-режим — из локальной копии Store
+type FallbackClient struct {
+	Online Client
+	Store  *Store
+
+	mu      sync.Mutex
+	offline bool // принудительный офлайн-режим
+}
+
+func NewFallbackClient(online Client, store *Store) *FallbackClient {
+	return &FallbackClient{Online: online, Store: store}
+}
+
+func (f *FallbackClient) SetOffline(offline bool) {
+	f.mu.Lock()
+	f.offline = offline
+	f.mu.Unlock()
+}
+
+func (f *FallbackClient) Offline() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.offline
+}
+
+func (f *FallbackClient) Refresh(year string) error {
+	if refresher, ok := f.Online.(Refresher); ok {
+		return refresher.Refresh(year)
+	}
+	return nil
+}
+
+func (f *FallbackClient) Seasons(ctx context.Context) ([]Season, error) {
+	return fallback(ctx, f, func(c Client) ([]Season, error) { return c.Seasons(ctx) })
+}
+
+func (f *FallbackClient) Season(ctx context.Context, year string) ([]Race, error) {
+	return fallback(ctx, f, func(c Client) ([]Race, error) { return c.Season(ctx, year) })
+}
+
+func (f *FallbackClient) Results(ctx context.Context, year, round string) ([]RaceResult, error) {
+	return fallback(ctx, f, func(c Client) ([]RaceResult, error) { return c.Results(ctx, year, round) })
+}
+
+func (f *FallbackClient) Drivers(ctx context.Context, year string) ([]Driver, error) {
+	return fallback(ctx, f, func(c Client) ([]Driver, error) { return c.Drivers(ctx, year) })
+}
+
+func (f *FallbackClient) Constructors(ctx context.Context, year string) ([]Constructor, error) {
+	return fallback(ctx, f, func(c Client) ([]Constructor, error) { return c.Constructors(ctx, year) })
+}
+
+func (f *FallbackClient) Driver(ctx context.Context, driverID string) (*Driver, error) {
+	return fallback(ctx, f, func(c Client) (*Driver, error) { return c.Driver(ctx, driverID) })
+}
+
+func (f *FallbackClient) DriverResults(ctx context.Context, driverID string) ([]Race, error) {
+	return fallback(ctx, f, func(c Client) ([]Race, error) { return c.DriverResults(ctx, driverID) })
+}
+
+func (f *FallbackClient) DriverSeasonStandings(ctx context.Context, driverID string) ([]StandingsList, error) {
+	return fallback(ctx, f, func(c Client) ([]StandingsList, error) { return c.DriverSeasonStandings(ctx, driverID) })
+}
+
+func (f *FallbackClient) ConstructorResults(ctx context.Context, constructorID string) ([]Race, error) {
+	return fallback(ctx, f, func(c Client) ([]Race, error) { return c.ConstructorResults(ctx, constructorID) })
+}
+
+func (f *FallbackClient) ConstructorSeasonStandings(ctx context.Context, constructorID string) ([]StandingsList, error) {
+	return fallback(ctx, f, func(c Client) ([]StandingsList, error) { return c.ConstructorSeasonStandings(ctx, constructorID) })
+}
+
+func (f *FallbackClient) Circuits(ctx context.Context, year string) ([]Circuit, error) {
+	return fallback(ctx, f, func(c Client) ([]Circuit, error) { return c.Circuits(ctx, year) })
+}
+
+func (f *FallbackClient) CircuitRaces(ctx context.Context, circuitID string) ([]Race, error) {
+	return fallback(ctx, f, func(c Client) ([]Race, error) { return c.CircuitRaces(ctx, circuitID) })
+}
+
+func (f *FallbackClient) Poles(ctx context.Context) ([]Race, error) {
+	return fallback(ctx, f, func(c Client) ([]Race, error) { return c.Poles(ctx) })
+}
+
+func (f *FallbackClient) Qualifying(ctx context.Context, year, round string) ([]QualifyingResult, error) {
+	return fallback(ctx, f, func(c Client) ([]QualifyingResult, error) { return c.Qualifying(ctx, year, round) })
+}
+
+func (f *FallbackClient) SprintResults(ctx context.Context, year, round string) ([]SprintResult, error) {
+	return fallback(ctx, f, func(c Client) ([]SprintResult, error) { return c.SprintResults(ctx, year, round) })
+}
+
+func (f *FallbackClient) Laps(ctx context.Context, year, round string) ([]Lap, error) {
+	return fallback(ctx, f, func(c Client) ([]Lap, error) { return c.Laps(ctx, year, round) })
+}
+
+func (f *FallbackClient) PitStops(ctx context.Context, year, round string) ([]PitStop, error) {
+	return fallback(ctx, f, func(c Client) ([]PitStop, error) { return c.PitStops(ctx, year, round) })
+}
+
+func (f *FallbackClient) DriverStandings(ctx context.Context, year, round string) ([]DriverStanding, error) {
+	return fallback(ctx, f, func(c Client) ([]DriverStanding, error) { return c.DriverStandings(ctx, year, round) })
+}
+
+func (f *FallbackClient) ConstructorStandings(ctx context.Context, year, round string) ([]ConstructorStanding, error) {
+	return fallback(ctx, f, func(c Client) ([]ConstructorStanding, error) { return c.ConstructorStandings(ctx, year, round) })
+}
+
+// fallback выполняет запрос онлайн, а при ошибке повторяет его в локальной копии.
+// Если и там данных нет, возвращается исходная сетевая ошибка. Ответ из локальной
+// копии Store сам отмечает в SourceRecorder контекста вместе с датой синхронизации
+func fallback[T any](ctx context.Context, f *FallbackClient, query func(c Client) (T, error)) (T, error) {
+	if !f.Offline() {
+		result, err := query(f.Online)
+		if err == nil {
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			return result, err // загрузку отменили, локальная копия тоже не нужна
+		}
+		stored, storeErr := query(f.Store)
+		if storeErr != nil {
+			return result, err
+		}
+		return stored, nil
+	}
+	return query(f.Store)
+}
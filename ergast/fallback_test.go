@@ -0,0 +1,121 @@
+package ergast
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFallbackClient(t *testing.T) {
+	store := testStore(t)
+	online := httptest.NewServer(NewStoreHandler(store)) // та же копия, но «по сети»
+	t.Cleanup(online.Close)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	tests := []struct {
+		name          string
+		baseURL       string
+		offline       bool
+		year          string
+		wantFromStore bool
+		wantErr       bool
+	}{
+		{"online", online.URL + "/api/f1", false, "2023", false, false},
+		{"network down", down.URL, false, "2023", true, false},
+		{"forced offline", online.URL + "/api/f1", true, "2023", true, false},
+		{"not synced", down.URL, false, "1999", false, true},
+		{"offline and not synced", online.URL + "/api/f1", true, "1999", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewFallbackClient(&HTTPClient{BaseURLs: []string{tt.baseURL}, HTTP: http.DefaultClient}, store)
+			client.SetOffline(tt.offline)
+			ctx, source := WithSourceRecorder(context.Background())
+
+			races, err := client.Season(ctx, tt.year)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("want error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Season: %v", err)
+			}
+			if len(races) != 3 {
+				t.Errorf("got %d races, want 3", len(races))
+			}
+			if source.FromStore() != tt.wantFromStore {
+				t.Errorf("FromStore = %v, want %v", source.FromStore(), tt.wantFromStore)
+			}
+			if tt.wantFromStore && source.Source() != "offline data as of 2024-01-10" {
+				t.Errorf("source = %q", source.Source())
+			}
+		})
+	}
+}
+
+// Источник у каждого запроса свой, даже если клиент общий
+func TestSourceRecorderPerRequest(t *testing.T) {
+	store := testStore(t)
+	online := httptest.NewServer(NewStoreHandler(store))
+	t.Cleanup(online.Close)
+	client := NewFallbackClient(&HTTPClient{BaseURLs: []string{online.URL + "/api/f1"}, HTTP: online.Client()}, store)
+
+	onlineCtx, onlineSource := WithSourceRecorder(context.Background())
+	if _, err := client.Season(onlineCtx, "2023"); err != nil {
+		t.Fatal(err)
+	}
+	storeCtx, storeSource := WithSourceRecorder(context.Background())
+	if _, err := client.Season(storeCtx, "1999"); err == nil { // в сети 404, в копии нет — ошибка
+		t.Fatal("want error for 1999")
+	}
+	if _, err := client.Results(context.Background(), "2023", "1"); err != nil { // без recorder — ничего не пишет
+		t.Fatal(err)
+	}
+	if onlineSource.FromStore() || onlineSource.Source() != online.URL+"/api/f1" {
+		t.Errorf("online request: source %q, fromStore %v", onlineSource.Source(), onlineSource.FromStore())
+	}
+	if storeSource.Source() != "" {
+		t.Errorf("failed request recorded source %q", storeSource.Source())
+	}
+}
+
+// Окна и фоновые загрузки читают копию одновременно: каждый запрос видит дату своего сезона
+func TestStoreSourceConcurrent(t *testing.T) {
+	store := NewStore(t.TempDir())
+	synced := map[string]time.Time{
+		"2021": time.Date(2022, 1, 5, 0, 0, 0, 0, time.UTC),
+		"2022": time.Date(2023, 2, 6, 0, 0, 0, 0, time.UTC),
+	}
+	for season, syncedAt := range synced {
+		if err := store.Save(&SeasonData{Season: season, SyncedAt: syncedAt, Races: []Race{{Round: "1"}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := NewFallbackClient(&HTTPClient{}, store)
+	client.SetOffline(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		for season, syncedAt := range synced {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, source := WithSourceRecorder(context.Background())
+				if _, err := client.Season(ctx, season); err != nil {
+					t.Error(err)
+					return
+				}
+				if want := offlineSource(syncedAt); source.Source() != want || !source.FromStore() {
+					t.Errorf("season %s: source %q, fromStore %v, want %q", season, source.Source(), source.FromStore(), want)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,34 @@
+package ergast
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseLapTime разбирает время круга Ergast: "1:29.708", "59.123" или "1:02:03.456"
+func ParseLapTime(s string) (time.Duration, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, false
+	}
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	total := time.Duration(seconds * float64(time.Second))
+	unit := time.Minute
+	for i := len(parts) - 2; i >= 0; i-- { // минуты, затем часы
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		total += time.Duration(n) * unit
+		unit = time.Hour
+	}
+	return total.Round(time.Millisecond), true
+}
@@ -0,0 +1,344 @@
+package ergast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Постраничная выдача как у оригинального Ergast
+const (
+	defaultLimit = 30
+	maxLimit     = 1000
+)
+
+// NewStoreHandler раздаёт локальную копию в формате Ergast (MRData/RaceTable/...,
+// limit/offset/total) по основным путям ergast.com/api/f1:
+//
+//	/api/f1/{year}.json
+//	/api/f1/{year}/{round}.json
+//	/api/f1/{year}/{round}/results.json, /api/f1/{year}/results.json
+//	/api/f1/{year}/{round}/qualifying.json, /api/f1/{year}/qualifying.json
+//	/api/f1/{year}/{round}/sprint.json, /api/f1/{year}/sprint.json
+//	/api/f1/{year}[/{round}]/drivers.json, .../constructors.json, .../circuits.json
+//	/api/f1/{year}/driverStandings.json, /api/f1/{year}/constructorStandings.json
+//	/api/f1/drivers/{id}.json
+//	/api/f1/seasons.json
+//
+// Вместо года можно писать "current", вместо этапа — "last" (последний проведённый)
+// и "next" (следующий). Остальные пути Ergast (круги, пит-стопы, /circuits/{id} и т.п.)
+// локальная копия не хранит — на них сервер отвечает 404
+func NewStoreHandler(store *Store) http.Handler {
+	return &storeHandler{store: store}
+}
+
+type storeHandler struct {
+	store *Store
+}
+
+func (h *storeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/api/f1/")
+	path = strings.TrimSuffix(path, ".json")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	data := &MRData{
+		Xmlns:  "http://ergast.com/mrd/1.5",
+		Series: "f1",
+		URL:    "http://" + r.Host + r.URL.Path,
+	}
+	limit, offset := pageParams(r)
+
+	var err error
+	if segments[0] == "drivers" && len(segments) == 2 {
+		err = h.driver(r.Context(), data, segments[1], limit, offset)
+	} else if segments[0] == "seasons" && len(segments) == 1 {
+		err = h.seasons(r.Context(), data, limit, offset)
+	} else {
+		err = h.season(data, segments, limit, offset)
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNotSynced) || errors.Is(err, ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	data.Limit = strconv.Itoa(limit)
+	data.Offset = strconv.Itoa(offset)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(Response{MRData: *data})
+}
+
+// season разбирает пути вида {year}[/{round}][/{kind}]
+func (h *storeHandler) season(data *MRData, segments []string, limit, offset int) error {
+	year := segments[0]
+	if year == "current" {
+		year = strconv.Itoa(time.Now().Year())
+	}
+	if _, err := strconv.Atoi(year); err != nil {
+		return fmt.Errorf("%w: unknown path", ErrNotFound)
+	}
+	season, err := h.store.Load(year)
+	if err != nil {
+		return err
+	}
+
+	round, kind := "", ""
+	switch len(segments) {
+	case 1:
+	case 2:
+		if isRound(segments[1]) {
+			round = segments[1]
+		} else {
+			kind = segments[1]
+		}
+	case 3:
+		if !isRound(segments[1]) {
+			return fmt.Errorf("%w: unknown path", ErrNotFound)
+		}
+		round, kind = segments[1], segments[2]
+	default:
+		return fmt.Errorf("%w: unknown path", ErrNotFound)
+	}
+
+	races := season.Races
+	if round != "" {
+		race, err := findRound(season, round)
+		if err != nil {
+			return err
+		}
+		round = race.Round
+		races = []Race{*race}
+	}
+
+	switch kind {
+	case "":
+		schedule := make([]Race, len(races))
+		for i, race := range races {
+			schedule[i] = onlyResults(race, "") // в расписании результатов нет
+		}
+		total, page := paginate(schedule, limit, offset)
+		data.Total = total
+		data.RaceTable = &RaceTable{Season: year, Round: round, Races: page}
+	case "results":
+		data.Total, races = paginateRaceRows(races, kind, limit, offset, func(race *Race) int { return len(race.Results) },
+			func(race *Race, from, to int) { race.Results = race.Results[from:to] })
+		data.RaceTable = &RaceTable{Season: year, Round: round, Races: races}
+	case "qualifying":
+		data.Total, races = paginateRaceRows(races, kind, limit, offset, func(race *Race) int { return len(race.QualifyingResults) },
+			func(race *Race, from, to int) { race.QualifyingResults = race.QualifyingResults[from:to] })
+		data.RaceTable = &RaceTable{Season: year, Round: round, Races: races}
+	case "sprint":
+		data.Total, races = paginateRaceRows(races, kind, limit, offset, func(race *Race) int { return len(race.SprintResults) },
+			func(race *Race, from, to int) { race.SprintResults = race.SprintResults[from:to] })
+		data.RaceTable = &RaceTable{Season: year, Round: round, Races: races}
+	case "drivers":
+		drivers := season.Drivers
+		if round != "" {
+			drivers = roundDrivers(races[0])
+		}
+		total, page := paginate(drivers, limit, offset)
+		data.Total = total
+		data.DriverTable = &DriverTable{Season: year, Drivers: page}
+	case "constructors":
+		constructors := season.Constructors
+		if round != "" {
+			constructors = roundConstructors(races[0])
+		}
+		total, page := paginate(constructors, limit, offset)
+		data.Total = total
+		data.ConstructorTable = &ConstructorTable{Season: year, Constructors: page}
+	case "circuits":
+		circuits := []Circuit{races[0].Circuit}
+		if round == "" {
+			var err error
+			if circuits, err = h.store.Circuits(context.Background(), year); err != nil {
+				return err
+			}
+		}
+		total, page := paginate(circuits, limit, offset)
+		data.Total = total
+		data.CircuitTable = &CircuitTable{Season: year, Circuits: page}
+	case "driverStandings", "constructorStandings":
+		saved, err := h.store.standings(context.Background(), year, round)
+		if err != nil {
+			return err
+		}
+		list := StandingsList{Season: year, Round: saved.Round}
+		if kind == "driverStandings" {
+			data.Total, list.DriverStandings = paginate(saved.DriverStandings, limit, offset)
+		} else {
+			data.Total, list.ConstructorStandings = paginate(saved.ConstructorStandings, limit, offset)
+		}
+		data.StandingsTable = &StandingsTable{Season: year, Round: saved.Round, StandingsLists: []StandingsList{list}}
+	default:
+		return fmt.Errorf("%w: unknown path", ErrNotFound)
+	}
+	return nil
+}
+
+func (h *storeHandler) driver(ctx context.Context, data *MRData, driverID string, limit, offset int) error {
+	driver, err := h.store.Driver(ctx, driverID)
+	if err != nil {
+		return err
+	}
+	total, page := paginate([]Driver{*driver}, limit, offset)
+	data.Total = total
+	data.DriverTable = &DriverTable{Drivers: page}
+	return nil
+}
+
+func (h *storeHandler) seasons(ctx context.Context, data *MRData, limit, offset int) error {
+	seasons, err := h.store.Seasons(ctx)
+	if err != nil {
+		return err
+	}
+	total, page := paginate(seasons, limit, offset)
+	data.Total = total
+	data.SeasonTable = &SeasonTable{Seasons: page}
+	return nil
+}
+
+// isRound — сегмент пути с номером этапа или "last"/"next"
+func isRound(segment string) bool {
+	if segment == "last" || segment == "next" {
+		return true
+	}
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+// findRound находит этап в расписании: по номеру, "last" — последний проведённый,
+// "next" — следующий за ним. ErrNotFound, если такого этапа нет
+func findRound(season *SeasonData, round string) (*Race, error) {
+	switch round {
+	case "last":
+		round = lastRound(season)
+	case "next":
+		last := lastRound(season)
+		if last == "" && len(season.Races) > 0 {
+			round = season.Races[0].Round // сезон ещё не начался
+		} else {
+			round = ""
+			for i, race := range season.Races {
+				if race.Round == last && i+1 < len(season.Races) {
+					round = season.Races[i+1].Round
+				}
+			}
+		}
+	}
+	for i := range season.Races {
+		if round != "" && season.Races[i].Round == round {
+			return &season.Races[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no such round", ErrNotFound)
+}
+
+// roundDrivers — пилоты, участвовавшие в этапе (по результатам, как у Ergast)
+func roundDrivers(race Race) []Driver {
+	var drivers []Driver
+	seen := make(map[string]bool)
+	for _, result := range race.Results {
+		if !seen[result.Driver.DriverID] {
+			seen[result.Driver.DriverID] = true
+			drivers = append(drivers, result.Driver)
+		}
+	}
+	sort.Slice(drivers, func(i, j int) bool { return drivers[i].DriverID < drivers[j].DriverID })
+	return drivers
+}
+
+// roundConstructors — команды, участвовавшие в этапе
+func roundConstructors(race Race) []Constructor {
+	var constructors []Constructor
+	seen := make(map[string]bool)
+	for _, result := range race.Results {
+		if !seen[result.Constructor.ConstructorID] {
+			seen[result.Constructor.ConstructorID] = true
+			constructors = append(constructors, result.Constructor)
+		}
+	}
+	sort.Slice(constructors, func(i, j int) bool { return constructors[i].ConstructorID < constructors[j].ConstructorID })
+	return constructors
+}
+
+// pageParams читает limit и offset из запроса
+func pageParams(r *http.Request) (limit, offset int) {
+	limit, offset = defaultLimit, 0
+	if value, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && value > 0 {
+		limit = min(value, maxLimit)
+	}
+	if value, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && value > 0 {
+		offset = value
+	}
+	return limit, offset
+}
+
+// paginate возвращает total в виде строки и срез страницы
+func paginate[T any](items []T, limit, offset int) (string, []T) {
+	total := len(items)
+	from := min(offset, total)
+	to := min(from+limit, total)
+	if items == nil {
+		items = []T{} // Ergast отдаёт пустой массив, а не null
+	}
+	return strconv.Itoa(total), items[from:to]
+}
+
+// paginateRaceRows листает строки результатов сквозь все гонки, как Ergast:
+// total — число строк, а на странице остаются только гонки, чьи строки в неё попали
+func paginateRaceRows(races []Race, kind string, limit, offset int, rows func(race *Race) int, cut func(race *Race, from, to int)) (string, []Race) {
+	total := 0
+	page := []Race{}
+	for _, race := range races {
+		race = onlyResults(race, kind)
+		count := rows(&race)
+		from := max(offset-total, 0)
+		to := min(offset+limit-total, count)
+		if from < to {
+			cut(&race, from, to)
+			page = append(page, race)
+		}
+		total += count
+	}
+	return strconv.Itoa(total), page
+}
+
+// onlyResults оставляет в гонке только таблицу kind ("results", "qualifying", "sprint"),
+// как в ответах Ergast; с пустым kind убирает все
+func onlyResults(race Race, kind string) Race {
+	results, qualifying, sprint := race.Results, race.QualifyingResults, race.SprintResults
+	race.Results, race.QualifyingResults, race.SprintResults = nil, nil, nil
+	switch kind {
+	case "results":
+		race.Results = results
+	case "qualifying":
+		race.QualifyingResults = qualifying
+	case "sprint":
+		race.SprintResults = sprint
+	}
+	return race
+}
+
+// lastRound — последний проведённый этап: на него рассчитан итоговый сохранённый зачёт
+func lastRound(season *SeasonData) string {
+	for i := len(season.Races) - 1; i >= 0; i-- {
+		if len(season.Races[i].Results) > 0 {
+			return season.Races[i].Round
+		}
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package ergast
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStoreHandler(t *testing.T) {
+	server := httptest.NewServer(NewStoreHandler(testStore(t)))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		check      func(t *testing.T, data MRData)
+	}{
+		{"/api/f1/2023.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if data.Total != "3" || len(data.RaceTable.Races) != 3 {
+				t.Errorf("total %s, %d races, want 3", data.Total, len(data.RaceTable.Races))
+			}
+			if len(data.RaceTable.Races[0].Results) != 0 {
+				t.Error("schedule contains results")
+			}
+		}},
+		{"/api/f1/2023.json?limit=1&offset=1", http.StatusOK, func(t *testing.T, data MRData) {
+			if data.Total != "3" || data.Limit != "1" || data.Offset != "1" {
+				t.Errorf("total/limit/offset = %s/%s/%s, want 3/1/1", data.Total, data.Limit, data.Offset)
+			}
+			if len(data.RaceTable.Races) != 1 || data.RaceTable.Races[0].Round != "2" {
+				t.Errorf("page = %+v, want round 2", data.RaceTable.Races)
+			}
+		}},
+		{"/api/f1/2023/1/results.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if races := data.RaceTable.Races; len(races) != 1 || len(races[0].Results) != 2 {
+				t.Errorf("races = %+v, want round 1 with 2 results", races)
+			}
+		}},
+		{"/api/f1/2023/results.json?limit=4", http.StatusOK, func(t *testing.T, data MRData) {
+			// 5 строк в двух гонках; на странице 4 — вся первая гонка и часть второй
+			races := data.RaceTable.Races
+			if data.Total != "5" || len(races) != 2 || len(races[1].Results) != 2 {
+				t.Errorf("total %s, races %+v, want 5 rows with 2+2 on the page", data.Total, races)
+			}
+		}},
+		{"/api/f1/2023/last/results.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if data.RaceTable.Round != "2" || len(data.RaceTable.Races) != 1 || data.RaceTable.Races[0].Round != "2" {
+				t.Errorf("last resolved to %+v, want round 2", data.RaceTable)
+			}
+		}},
+		{"/api/f1/2023/next.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if len(data.RaceTable.Races) != 1 || data.RaceTable.Races[0].Round != "3" {
+				t.Errorf("next resolved to %+v, want round 3", data.RaceTable.Races)
+			}
+		}},
+		{"/api/f1/2023/1/qualifying.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if races := data.RaceTable.Races; len(races) != 1 || len(races[0].QualifyingResults) != 1 {
+				t.Errorf("races = %+v, want one pole", races)
+			}
+		}},
+		{"/api/f1/2023/drivers.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if len(data.DriverTable.Drivers) != 3 {
+				t.Errorf("%d drivers, want 3", len(data.DriverTable.Drivers))
+			}
+		}},
+		{"/api/f1/2023/1/drivers.json", http.StatusOK, func(t *testing.T, data MRData) {
+			drivers := data.DriverTable.Drivers
+			if len(drivers) != 2 || drivers[0].DriverID != "alonso" || drivers[1].DriverID != "max_verstappen" {
+				t.Errorf("drivers = %+v, want alonso and max_verstappen", drivers)
+			}
+		}},
+		{"/api/f1/2023/1/constructors.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if len(data.ConstructorTable.Constructors) != 2 {
+				t.Errorf("constructors = %+v, want 2", data.ConstructorTable.Constructors)
+			}
+		}},
+		{"/api/f1/2023/2/circuits.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if circuits := data.CircuitTable.Circuits; len(circuits) != 1 || circuits[0].CircuitID != "jeddah" {
+				t.Errorf("circuits = %+v, want jeddah", circuits)
+			}
+		}},
+		{"/api/f1/2023/circuits.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if len(data.CircuitTable.Circuits) != 3 {
+				t.Errorf("%d circuits, want 3", len(data.CircuitTable.Circuits))
+			}
+		}},
+		{"/api/f1/2023/driverStandings.json", http.StatusOK, func(t *testing.T, data MRData) {
+			lists := data.StandingsTable.StandingsLists
+			if len(lists) != 1 || len(lists[0].DriverStandings) != 3 || lists[0].Round != "2" || data.StandingsTable.Round != "2" {
+				t.Errorf("standings = %+v, want 3 drivers after round 2", data.StandingsTable)
+			}
+		}},
+		{"/api/f1/2023/last/driverStandings.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if data.StandingsTable.Round != "2" || data.StandingsTable.StandingsLists[0].Round != "2" {
+				t.Errorf("last resolved to %+v, want round 2", data.StandingsTable)
+			}
+		}},
+		{"/api/f1/drivers/hamilton.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if drivers := data.DriverTable.Drivers; len(drivers) != 1 || drivers[0].DriverID != "hamilton" {
+				t.Errorf("drivers = %+v, want hamilton", drivers)
+			}
+		}},
+		{"/api/f1/seasons.json", http.StatusOK, func(t *testing.T, data MRData) {
+			if seasons := data.SeasonTable.Seasons; len(seasons) != 1 || seasons[0].Season != "2023" {
+				t.Errorf("seasons = %+v, want 2023", seasons)
+			}
+		}},
+		{"/api/f1/2023/7/results.json", http.StatusNotFound, nil},
+		{"/api/f1/2022.json", http.StatusNotFound, nil},
+		{"/api/f1/2023/1/laps.json", http.StatusNotFound, nil},
+		{"/api/f1/2023/1/pitstops.json", http.StatusNotFound, nil},
+		{"/api/f1/2023/drivers/hamilton.json", http.StatusNotFound, nil},
+		{"/api/f1/circuits/bahrain.json", http.StatusNotFound, nil},
+		{"/api/f1/constructors/mercedes.json", http.StatusNotFound, nil},
+		{"/api/f1/drivers/senna.json", http.StatusNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.check == nil {
+				return
+			}
+			var body Response
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding: %v", err)
+			}
+			tt.check(t, body.MRData)
+		})
+	}
+}
+
+func TestStoreHandlerMethod(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewStoreHandler(testStore(t)).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/f1/2023.json", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+// Клиент читает сервер локальной копии так же, как Jolpica
+func TestHTTPClientReadsStoreHandler(t *testing.T) {
+	server := httptest.NewServer(NewStoreHandler(testStore(t)))
+	t.Cleanup(server.Close)
+	client := &HTTPClient{BaseURLs: []string{server.URL + "/api/f1"}, HTTP: server.Client()}
+
+	results, err := client.Results(t.Context(), "2023", "2")
+	if err != nil {
+		t.Fatalf("Results: %v", err)
+	}
+	if len(results) != 3 || results[0].Driver.DriverID != "hamilton" {
+		t.Errorf("results = %+v, want 3 with hamilton first", results)
+	}
+}
@@ -0,0 +1,49 @@
+package ergast
+
+import (
+	"context"
+	"sync"
+)
+
+// SourceRecorder запоминает, откуда пришли ответы на запросы с его контекстом.
+// Клиент общий для всех окон и фоновых загрузок, поэтому источник хранится
+// у запроса, а не в клиенте
+type SourceRecorder struct {
+	mu        sync.Mutex
+	source    string
+	fromStore bool
+}
+
+type sourceRecorderKey struct{}
+
+// WithSourceRecorder возвращает контекст, запросы с которым записывают свой источник в recorder
+func WithSourceRecorder(ctx context.Context) (context.Context, *SourceRecorder) {
+	recorder := &SourceRecorder{}
+	return context.WithValue(ctx, sourceRecorderKey{}, recorder), recorder
+}
+
+// Source — источник последнего успешного ответа: адрес API, CacheSource или описание локальной копии
+func (r *SourceRecorder) Source() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.source
+}
+
+// FromStore сообщает, что хотя бы один ответ взят из локальной копии
+func (r *SourceRecorder) FromStore() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fromStore
+}
+
+// recordSource записывает источник ответа, если у контекста есть SourceRecorder
+func recordSource(ctx context.Context, source string, fromStore bool) {
+	recorder, ok := ctx.Value(sourceRecorderKey{}).(*SourceRecorder)
+	if !ok {
+		return
+	}
+	recorder.mu.Lock()
+	recorder.source = source
+	recorder.fromStore = recorder.fromStore || fromStore
+	recorder.mu.Unlock()
+}
@@ -0,0 +1,516 @@
+package ergast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotSynced — нужных данных нет в локальной копии
+var ErrNotSynced = errors.New("not available in offline data")
+
+// SeasonData — всё, что сохраняется о сезоне для работы без сети.
+// Результаты гонок и квалификаций лежат внутри Races, как в ответах Ergast
+type SeasonData struct {
+	Season               string                `json:"season"`
+	SyncedAt             time.Time             `json:"syncedAt"`
+	Races                []Race                `json:"races"`
+	Drivers              []Driver              `json:"drivers"`
+	Constructors         []Constructor         `json:"constructors"`
+	DriverStandings      []DriverStanding      `json:"driverStandings"`
+	ConstructorStandings []ConstructorStanding `json:"constructorStandings"`
+	RoundStandings       []StandingsList       `json:"roundStandings,omitempty"` // положение после каждого этапа; в старых копиях его нет
+}
+
+// Store — локальная копия сезонов на диске, по файлу на сезон.
+// Реализует Client, поэтому UI читает из неё так же, как из сети
+type Store struct {
+	Dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// Load читает сезон из локальной копии
+func (s *Store) Load(year string) (*SeasonData, error) {
+	body, err := os.ReadFile(s.file(year))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotSynced
+	}
+	if err != nil {
+		return nil, err
+	}
+	var data SeasonData
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("parsing offline data: %w", err)
+	}
+	return &data, nil
+}
+
+// read — Load, который отмечает в SourceRecorder контекста дату синхронизации прочитанного сезона
+func (s *Store) read(ctx context.Context, year string) (*SeasonData, error) {
+	data, err := s.Load(year)
+	if err == nil {
+		recordSource(ctx, offlineSource(data.SyncedAt), true)
+	}
+	return data, err
+}
+
+func (s *Store) Save(data *SeasonData) error {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	file := s.file(data.Season)
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+func (s *Store) file(year string) string {
+	return filepath.Join(s.Dir, year+".json")
+}
+
+// offlineSource — источник для SourceRecorder, когда ответ взят из локальной копии
+func offlineSource(syncedAt time.Time) string {
+	return "offline data as of " + syncedAt.Format("2006-01-02")
+}
+
+// Seasons — сохранённые сезоны; ErrNotSynced, если не сохранено ни одного
+func (s *Store) Seasons(ctx context.Context) ([]Season, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotSynced
+	}
+	if err != nil {
+		return nil, err
+	}
+	var years []int
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json") // недописанные .tmp сюда не попадают
+		if year, err := strconv.Atoi(name); ok && err == nil {
+			years = append(years, year)
+		}
+	}
+	if len(years) == 0 {
+		return nil, ErrNotSynced
+	}
+	sort.Ints(years)
+	recordSource(ctx, "offline data", true) // сами сезоны не читаем, поэтому без даты
+	seasons := make([]Season, len(years))
+	for i, year := range years {
+		seasons[i] = Season{
+			Season: strconv.Itoa(year),
+			URL:    fmt.Sprintf("https://en.wikipedia.org/wiki/%d_Formula_One_World_Championship", year),
+		}
+	}
+	return seasons, nil
+}
+
+func (s *Store) Season(ctx context.Context, year string) ([]Race, error) {
+	data, err := s.read(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	return data.Races, nil
+}
+
+func (s *Store) Results(ctx context.Context, year, round string) ([]RaceResult, error) {
+	race, err := s.race(ctx, year, round)
+	if err != nil {
+		return nil, err
+	}
+	return race.Results, nil
+}
+
+func (s *Store) Qualifying(ctx context.Context, year, round string) ([]QualifyingResult, error) {
+	race, err := s.race(ctx, year, round)
+	if err != nil {
+		return nil, err
+	}
+	return race.QualifyingResults, nil
+}
+
+func (s *Store) SprintResults(ctx context.Context, year, round string) ([]SprintResult, error) {
+	race, err := s.race(ctx, year, round)
+	if err != nil {
+		return nil, err
+	}
+	return race.SprintResults, nil
+}
+
+// Laps и PitStops в локальную копию не сохраняются: это десятки запросов на каждую гонку
+func (s *Store) Laps(_ context.Context, year, round string) ([]Lap, error) {
+	return nil, ErrNotSynced
+}
+
+func (s *Store) PitStops(_ context.Context, year, round string) ([]PitStop, error) {
+	return nil, ErrNotSynced
+}
+
+func (s *Store) Drivers(ctx context.Context, year string) ([]Driver, error) {
+	data, err := s.read(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	return data.Drivers, nil
+}
+
+func (s *Store) Constructors(ctx context.Context, year string) ([]Constructor, error) {
+	data, err := s.read(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	return data.Constructors, nil
+}
+
+// Driver ищет пилота в сохранённых сезонах, начиная с последнего
+func (s *Store) Driver(ctx context.Context, driverID string) (*Driver, error) {
+	for year := time.Now().Year(); year >= 1950; year-- {
+		data, err := s.Load(strconv.Itoa(year))
+		if err != nil {
+			continue
+		}
+		for i := range data.Drivers {
+			if data.Drivers[i].DriverID == driverID {
+				recordSource(ctx, offlineSource(data.SyncedAt), true)
+				return &data.Drivers[i], nil
+			}
+		}
+	}
+	return nil, ErrNotSynced
+}
+
+func (s *Store) DriverResults(ctx context.Context, driverID string) ([]Race, error) {
+	var races []Race
+	err := s.eachSeason(ctx, func(data *SeasonData) {
+		for _, race := range data.Races {
+			for _, result := range race.Results {
+				if result.Driver.DriverID == driverID {
+					race.Season = data.Season
+					race.Results = []RaceResult{result}
+					race.QualifyingResults, race.SprintResults, race.Laps, race.PitStops = nil, nil, nil, nil
+					races = append(races, race)
+					break
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(races) == 0 {
+		return nil, ErrNotSynced
+	}
+	return races, nil
+}
+
+func (s *Store) DriverSeasonStandings(ctx context.Context, driverID string) ([]StandingsList, error) {
+	var lists []StandingsList
+	err := s.eachSeason(ctx, func(data *SeasonData) {
+		for _, standing := range data.DriverStandings {
+			if standing.Driver.DriverID == driverID {
+				lists = append(lists, StandingsList{Season: data.Season, Round: lastRound(data), DriverStandings: []DriverStanding{standing}})
+				break
+			}
+		}
+	})
+	return lists, err
+}
+
+func (s *Store) ConstructorResults(ctx context.Context, constructorID string) ([]Race, error) {
+	var races []Race
+	err := s.eachSeason(ctx, func(data *SeasonData) {
+		for _, race := range data.Races {
+			var results []RaceResult
+			for _, result := range race.Results {
+				if result.Constructor.ConstructorID == constructorID {
+					results = append(results, result)
+				}
+			}
+			if len(results) == 0 {
+				continue
+			}
+			race.Season = data.Season
+			race.Results = results
+			race.QualifyingResults, race.SprintResults, race.Laps, race.PitStops = nil, nil, nil, nil
+			races = append(races, race)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(races) == 0 {
+		return nil, ErrNotSynced
+	}
+	return races, nil
+}
+
+func (s *Store) ConstructorSeasonStandings(ctx context.Context, constructorID string) ([]StandingsList, error) {
+	var lists []StandingsList
+	err := s.eachSeason(ctx, func(data *SeasonData) {
+		for _, standing := range data.ConstructorStandings {
+			if standing.Constructor.ConstructorID == constructorID {
+				lists = append(lists, StandingsList{Season: data.Season, Round: lastRound(data), ConstructorStandings: []ConstructorStanding{standing}})
+				break
+			}
+		}
+	})
+	return lists, err
+}
+
+func (s *Store) Circuits(ctx context.Context, year string) ([]Circuit, error) {
+	var circuits []Circuit
+	seen := make(map[string]bool)
+	collect := func(data *SeasonData) {
+		for _, race := range data.Races {
+			if !seen[race.Circuit.CircuitID] {
+				seen[race.Circuit.CircuitID] = true
+				circuits = append(circuits, race.Circuit)
+			}
+		}
+	}
+	if year != "" {
+		data, err := s.read(ctx, year)
+		if err != nil {
+			return nil, err
+		}
+		collect(data)
+		return circuits, nil
+	}
+	if err := s.eachSeason(ctx, collect); err != nil {
+		return nil, err
+	}
+	sort.Slice(circuits, func(i, j int) bool { return circuits[i].CircuitID < circuits[j].CircuitID }) // как у Ergast
+	return circuits, nil
+}
+
+func (s *Store) CircuitRaces(ctx context.Context, circuitID string) ([]Race, error) {
+	var races []Race
+	err := s.eachSeason(ctx, func(data *SeasonData) {
+		for _, race := range data.Races {
+			if race.Circuit.CircuitID != circuitID {
+				continue
+			}
+			race.Season = data.Season
+			race.Results = firstPlace(race.Results, func(r RaceResult) string { return r.Position })
+			race.QualifyingResults = firstPlace(race.QualifyingResults, func(r QualifyingResult) string { return r.Position })
+			race.SprintResults, race.Laps, race.PitStops = nil, nil, nil
+			races = append(races, race)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(races) == 0 {
+		return nil, ErrNotSynced
+	}
+	return races, nil
+}
+
+func (s *Store) Poles(ctx context.Context) ([]Race, error) {
+	var races []Race
+	err := s.eachSeason(ctx, func(data *SeasonData) {
+		for _, race := range data.Races {
+			pole := firstPlace(race.QualifyingResults, func(r QualifyingResult) string { return r.Position })
+			if len(pole) == 0 {
+				continue
+			}
+			race.Season = data.Season
+			race.QualifyingResults = pole
+			race.Results, race.SprintResults, race.Laps, race.PitStops = nil, nil, nil, nil
+			races = append(races, race)
+		}
+	})
+	return races, err
+}
+
+// firstPlace оставляет только строку с первым местом
+func firstPlace[T any](rows []T, position func(T) string) []T {
+	for _, row := range rows {
+		if position(row) == "1" {
+			return []T{row}
+		}
+	}
+	return nil
+}
+
+// eachSeason передаёт в fn все сохранённые сезоны по порядку. ErrNotSynced — если нет ни одного.
+// В SourceRecorder попадает самая ранняя дата синхронизации среди прочитанных сезонов
+func (s *Store) eachSeason(ctx context.Context, fn func(data *SeasonData)) error {
+	var oldest time.Time
+	found := false
+	for year := 1950; year <= time.Now().Year(); year++ {
+		data, err := s.Load(strconv.Itoa(year))
+		if errors.Is(err, ErrNotSynced) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !found || data.SyncedAt.Before(oldest) {
+			oldest = data.SyncedAt
+		}
+		found = true
+		fn(data)
+	}
+	if !found {
+		return ErrNotSynced
+	}
+	recordSource(ctx, offlineSource(oldest), true)
+	return nil
+}
+
+func (s *Store) DriverStandings(ctx context.Context, year, round string) ([]DriverStanding, error) {
+	list, err := s.standings(ctx, year, round)
+	if err != nil {
+		return nil, err
+	}
+	return list.DriverStandings, nil
+}
+
+func (s *Store) ConstructorStandings(ctx context.Context, year, round string) ([]ConstructorStanding, error) {
+	list, err := s.standings(ctx, year, round)
+	if err != nil {
+		return nil, err
+	}
+	return list.ConstructorStandings, nil
+}
+
+// standings — положение после этапа round. Пустой round или последний
+// проведённый этап — итоговое положение на момент синхронизации
+func (s *Store) standings(ctx context.Context, year, round string) (*StandingsList, error) {
+	data, err := s.read(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	if last := lastRound(data); round == "" || round == last {
+		return &StandingsList{
+			Season:               year,
+			Round:                last,
+			DriverStandings:      data.DriverStandings,
+			ConstructorStandings: data.ConstructorStandings,
+		}, nil
+	}
+	for i := range data.RoundStandings {
+		if data.RoundStandings[i].Round == round {
+			return &data.RoundStandings[i], nil
+		}
+	}
+	return nil, ErrNotSynced
+}
+
+func (s *Store) race(ctx context.Context, year, round string) (*Race, error) {
+	data, err := s.read(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	for i := range data.Races {
+		if data.Races[i].Round == round {
+			return &data.Races[i], nil
+		}
+	}
+	return nil, ErrNotSynced
+}
+
+// Sync скачивает сезоны from..to из src в store. Сезоны, сохранённые уже
+// после своего окончания, повторно не качаются, поэтому прерванную синхронизацию
+// можно запустить снова — она продолжится с первого недокачанного сезона.
+// Если сервер ограничил частоту запросов, сезон качается заново после паузы
+// (уже скачанные страницы отдаст кэш HTTPClient). progress вызывается перед каждым сезоном
+func Sync(ctx context.Context, src Client, store *Store, from, to int, progress func(year int)) error {
+	for year := from; year <= to; year++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if progress != nil {
+			progress(year)
+		}
+		season := fmt.Sprint(year)
+		if stored, err := store.Load(season); err == nil && stored.SyncedAt.Year() > year && !missesRoundStandings(stored) {
+			continue
+		}
+		data, err := syncSeason(ctx, src, season)
+		for attempt := 0; attempt < maxRetries; attempt++ {
+			statusErr, limited := tooManyRequests(err)
+			if !limited {
+				break
+			}
+			if err := sleep(ctx, statusErr.retryDelay(time.Minute<<attempt)); err != nil {
+				return err
+			}
+			data, err = syncSeason(ctx, src, season)
+		}
+		if err != nil {
+			return fmt.Errorf("season %s: %w", season, err)
+		}
+		if err := store.Save(data); err != nil {
+			return fmt.Errorf("saving season %s: %w", season, err)
+		}
+	}
+	return nil
+}
+
+// missesRoundStandings — копия сохранена до того, как в неё стали класть зачёт по этапам
+func missesRoundStandings(data *SeasonData) bool {
+	return len(data.RoundStandings) == 0 && lastRound(data) != ""
+}
+
+func syncSeason(ctx context.Context, src Client, season string) (*SeasonData, error) {
+	data := &SeasonData{Season: season, SyncedAt: time.Now()}
+	var err error
+	if data.Races, err = src.Season(ctx, season); err != nil {
+		return nil, err
+	}
+	for i := range data.Races {
+		race := &data.Races[i]
+		if race.Results, err = src.Results(ctx, season, race.Round); err != nil {
+			return nil, err
+		}
+		if race.QualifyingResults, err = src.Qualifying(ctx, season, race.Round); err != nil {
+			return nil, err
+		}
+		if len(race.Results) > 0 { // зачёт есть только после проведённых этапов
+			list := StandingsList{Season: season, Round: race.Round}
+			if list.DriverStandings, err = src.DriverStandings(ctx, season, race.Round); err != nil {
+				return nil, err
+			}
+			if list.ConstructorStandings, err = src.ConstructorStandings(ctx, season, race.Round); err != nil {
+				return nil, err
+			}
+			data.RoundStandings = append(data.RoundStandings, list)
+		}
+		if race.Sprint == nil {
+			continue // обычный этап, спринта не было
+		}
+		if race.SprintResults, err = src.SprintResults(ctx, season, race.Round); err != nil {
+			return nil, err
+		}
+	}
+	if data.Drivers, err = src.Drivers(ctx, season); err != nil {
+		return nil, err
+	}
+	if data.Constructors, err = src.Constructors(ctx, season); err != nil {
+		return nil, err
+	}
+	if data.DriverStandings, err = src.DriverStandings(ctx, season, ""); err != nil {
+		return nil, err
+	}
+	if data.ConstructorStandings, err = src.ConstructorStandings(ctx, season, ""); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,140 @@
+package ergast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testStore — локальная копия с сезоном 2023 из трёх этапов, из которых проведены два
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	store := NewStore(t.TempDir())
+	hamilton := Driver{DriverID: "hamilton"}
+	verstappen := Driver{DriverID: "max_verstappen"}
+	alonso := Driver{DriverID: "alonso"}
+	mercedes := Constructor{ConstructorID: "mercedes"}
+	redBull := Constructor{ConstructorID: "red_bull"}
+	astonMartin := Constructor{ConstructorID: "aston_martin"}
+	data := &SeasonData{
+		Season:   "2023",
+		SyncedAt: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		Races: []Race{
+			{Round: "1", RaceName: "Bahrain Grand Prix", Circuit: Circuit{CircuitID: "bahrain"},
+				Results: []RaceResult{
+					{Position: "1", Driver: verstappen, Constructor: redBull},
+					{Position: "2", Driver: alonso, Constructor: astonMartin},
+				},
+				QualifyingResults: []QualifyingResult{{Position: "1", Driver: verstappen, Constructor: redBull}},
+			},
+			{Round: "2", RaceName: "Saudi Arabian Grand Prix", Circuit: Circuit{CircuitID: "jeddah"},
+				Results: []RaceResult{
+					{Position: "1", Driver: hamilton, Constructor: mercedes},
+					{Position: "2", Driver: verstappen, Constructor: redBull},
+					{Position: "3", Driver: alonso, Constructor: astonMartin},
+				},
+			},
+			{Round: "3", RaceName: "Australian Grand Prix", Circuit: Circuit{CircuitID: "albert_park"}},
+		},
+		Drivers:      []Driver{alonso, hamilton, verstappen},
+		Constructors: []Constructor{astonMartin, mercedes, redBull},
+		DriverStandings: []DriverStanding{
+			{Position: "1", Driver: verstappen},
+			{Position: "2", Driver: hamilton},
+			{Position: "3", Driver: alonso},
+		},
+		RoundStandings: []StandingsList{
+			{Season: "2023", Round: "1", DriverStandings: []DriverStanding{{Position: "1", Driver: verstappen}, {Position: "2", Driver: alonso}}},
+			{Season: "2023", Round: "2", DriverStandings: []DriverStanding{{Position: "1", Driver: verstappen}, {Position: "2", Driver: hamilton}, {Position: "3", Driver: alonso}}},
+		},
+	}
+	if err := store.Save(data); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return store
+}
+
+func TestSync(t *testing.T) {
+	source := testStore(t)
+	handler := NewStoreHandler(source)
+
+	tests := []struct {
+		name        string
+		limited     int32 // сколько первых ответов — 429 (без срока: повтор через секунду)
+		wantSkipped bool  // сезон уже сохранён после окончания — запросов нет
+	}{
+		{"fresh sync", 0, false},
+		{"rate limited", 1, false},
+		{"already stored", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.limited {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				handler.ServeHTTP(w, r)
+			}))
+			t.Cleanup(server.Close)
+			client := &HTTPClient{BaseURLs: []string{server.URL + "/api/f1"}, HTTP: server.Client()}
+			store := NewStore(t.TempDir())
+			if tt.wantSkipped {
+				if err := store.Save(&SeasonData{Season: "2023", SyncedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := Sync(context.Background(), client, store, 2023, 2023, nil)
+			if err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+			if tt.wantSkipped {
+				if got := requests.Load(); got != 0 {
+					t.Errorf("made %d requests for a stored season", got)
+				}
+				return
+			}
+			data, err := store.Load("2023")
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if len(data.Races) != 3 || len(data.Races[1].Results) != 3 || len(data.RoundStandings) != 2 {
+				body, _ := json.Marshal(data)
+				t.Errorf("synced season is incomplete: %s", body)
+			}
+		})
+	}
+}
+
+func TestSyncStopsOnLongRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+	client := &HTTPClient{BaseURLs: []string{server.URL}, HTTP: server.Client()}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond) // Sync ждал бы час — отменяем
+	defer cancel()
+
+	err := Sync(ctx, client, NewStore(t.TempDir()), 2023, 2023, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context deadline", err)
+	}
+}
+
+func TestStorePoles(t *testing.T) {
+	poles, err := testStore(t).Poles(context.Background())
+	if err != nil {
+		t.Fatalf("Poles: %v", err)
+	}
+	if len(poles) != 1 || poles[0].Round != "1" || poles[0].Season != "2023" || poles[0].QualifyingResults[0].Driver.DriverID != "max_verstappen" {
+		t.Errorf("poles = %+v, want max_verstappen in round 1", poles)
+	}
+}
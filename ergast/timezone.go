@@ -0,0 +1,127 @@
+package ergast
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	_ "time/tzdata" // база часовых поясов встроена: на Windows и в браузере системной нет
+)
+
+// zonePoint — точка с известным часовым поясом (трассы Ф1 и крупные города рядом с ними)
+type zonePoint struct {
+	zone      string
+	lat, long float64
+}
+
+var zonePoints = []zonePoint{
+	{"Europe/London", 52.07, -1.02}, // Silverstone
+	{"Europe/London", 51.36, 0.26},  // Brands Hatch
+	{"Europe/London", 53.48, -2.94}, // Aintree
+	{"Europe/London", 52.83, -1.38}, // Donington
+	{"Europe/Paris", 46.86, 3.16},   // Magny-Cours
+	{"Europe/Paris", 43.25, 5.79},   // Paul Ricard
+	{"Europe/Paris", 49.25, 4.03},   // Reims
+	{"Europe/Paris", 49.33, 1.0},    // Rouen
+	{"Europe/Paris", 45.75, 3.03},   // Clermont-Ferrand
+	{"Europe/Paris", 47.36, 4.9},    // Dijon
+	{"Europe/Paris", 47.95, 0.22},   // Le Mans
+	{"Europe/Monaco", 43.73, 7.42},
+	{"Europe/Rome", 45.62, 9.28},      // Monza
+	{"Europe/Rome", 44.34, 11.71},     // Imola
+	{"Europe/Rome", 43.99, 11.37},     // Mugello
+	{"Europe/Rome", 42.47, 14.2},      // Pescara
+	{"Europe/Berlin", 49.33, 8.57},    // Hockenheim
+	{"Europe/Berlin", 50.33, 6.94},    // Nürburgring
+	{"Europe/Berlin", 52.48, 13.25},   // AVUS
+	{"Europe/Zurich", 46.96, 7.4},     // Bremgarten
+	{"Europe/Brussels", 50.44, 5.97},  // Spa
+	{"Europe/Brussels", 50.99, 5.26},  // Zolder
+	{"Europe/Brussels", 50.62, 4.33},  // Nivelles
+	{"Europe/Amsterdam", 52.39, 4.54}, // Zandvoort
+	{"Europe/Madrid", 41.57, 2.26},    // Barcelona
+	{"Europe/Madrid", 36.71, -6.03},   // Jerez
+	{"Europe/Madrid", 40.62, -3.59},   // Jarama
+	{"Europe/Madrid", 39.46, -0.33},   // Valencia
+	{"Europe/Lisbon", 38.75, -9.39},   // Estoril
+	{"Europe/Lisbon", 37.23, -8.63},   // Portimão
+	{"Europe/Lisbon", 41.17, -8.68},   // Porto
+	{"Europe/Vienna", 47.22, 14.76},   // Spielberg
+	{"Europe/Budapest", 47.58, 19.25},
+	{"Europe/Stockholm", 57.26, 13.6}, // Anderstorp
+	{"Europe/Istanbul", 40.95, 29.41},
+	{"Europe/Moscow", 43.41, 39.96}, // Sochi
+	{"Asia/Baku", 40.37, 49.85},
+	{"Asia/Bahrain", 26.03, 50.51},
+	{"Asia/Qatar", 25.49, 51.45},
+	{"Asia/Riyadh", 21.63, 39.1}, // Jeddah
+	{"Asia/Dubai", 24.47, 54.6},  // Yas Marina
+	{"Asia/Kolkata", 28.35, 77.53},
+	{"Asia/Singapore", 1.29, 103.86},
+	{"Asia/Kuala_Lumpur", 2.76, 101.74},
+	{"Asia/Shanghai", 31.34, 121.22},
+	{"Asia/Tokyo", 34.84, 136.54}, // Suzuka
+	{"Asia/Tokyo", 35.37, 138.93}, // Fuji
+	{"Asia/Tokyo", 34.92, 134.22}, // Aida
+	{"Asia/Seoul", 34.73, 126.42}, // Yeongam
+	{"Australia/Melbourne", -37.85, 144.97},
+	{"Australia/Adelaide", -34.93, 138.62},
+	{"Africa/Johannesburg", -25.99, 28.08}, // Kyalami
+	{"Africa/Johannesburg", -33.05, 27.87}, // East London
+	{"Africa/Casablanca", 33.58, -7.69},
+	{"America/Toronto", 45.5, -73.52},   // Montreal
+	{"America/Toronto", 44.05, -78.68},  // Mosport
+	{"America/Toronto", 46.19, -74.61},  // Mont-Tremblant
+	{"America/New_York", 42.34, -76.93}, // Watkins Glen
+	{"America/New_York", 27.45, -81.35}, // Sebring
+	{"America/New_York", 25.96, -80.24}, // Miami
+	{"America/Chicago", 30.13, -97.64},  // Austin
+	{"America/Chicago", 32.78, -96.8},   // Dallas
+	{"America/Indiana/Indianapolis", 39.79, -86.23},
+	{"America/Detroit", 42.33, -83.04},
+	{"America/Phoenix", 33.45, -112.07},
+	{"America/Los_Angeles", 33.76, -118.19}, // Long Beach
+	{"America/Los_Angeles", 33.94, -117.27}, // Riverside
+	{"America/Los_Angeles", 36.11, -115.17}, // Las Vegas
+	{"America/Mexico_City", 19.4, -99.09},
+	{"America/Sao_Paulo", -23.7, -46.7},  // Interlagos
+	{"America/Sao_Paulo", -22.97, -43.4}, // Jacarepaguá
+	{"America/Argentina/Buenos_Aires", -34.69, -58.46},
+}
+
+// maxZoneDistanceKm — дальше ближайшей известной точки пояс не угадываем,
+// а берём смещение по долготе
+const maxZoneDistanceKm = 800
+
+// TimeZone определяет часовой пояс трассы по координатам без обращения к сети:
+// берётся пояс ближайшей известной точки, а если рядом таких нет — UTC±N по долготе
+func (l Location) TimeZone() *time.Location {
+	lat, latErr := strconv.ParseFloat(l.Lat, 64)
+	long, longErr := strconv.ParseFloat(l.Long, 64)
+	if latErr != nil || longErr != nil {
+		return time.UTC
+	}
+	nearest, best := "", math.Inf(1)
+	for _, point := range zonePoints {
+		if d := distanceKm(lat, long, point.lat, point.long); d < best {
+			nearest, best = point.zone, d
+		}
+	}
+	if best <= maxZoneDistanceKm {
+		if zone, err := time.LoadLocation(nearest); err == nil {
+			return zone
+		}
+	}
+	offset := int(math.Round(long / 15))
+	return time.FixedZone(fmt.Sprintf("UTC%+d", offset), offset*3600)
+}
+
+// distanceKm — расстояние по дуге большого круга
+func distanceKm(lat1, long1, lat2, long2 float64) float64 {
+	const earthRadiusKm = 6371
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLong := (long2 - long1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
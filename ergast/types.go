@@ -0,0 +1,271 @@
+package ergast
+
+import (
+	"sort"
+	"time"
+)
+
+// Response — корневой объект любого ответа Ergast API
+type Response struct {
+	MRData MRData `json:"MRData"`
+}
+
+type MRData struct {
+	Xmlns            string            `json:"xmlns"`
+	Series           string            `json:"series"`
+	URL              string            `json:"url"`
+	Limit            string            `json:"limit"`
+	Offset           string            `json:"offset"`
+	Total            string            `json:"total"`
+	RaceTable        *RaceTable        `json:"RaceTable,omitempty"`
+	DriverTable      *DriverTable      `json:"DriverTable,omitempty"`
+	ConstructorTable *ConstructorTable `json:"ConstructorTable,omitempty"`
+	CircuitTable     *CircuitTable     `json:"CircuitTable,omitempty"`
+	SeasonTable      *SeasonTable      `json:"SeasonTable,omitempty"`
+	StandingsTable   *StandingsTable   `json:"StandingsTable,omitempty"`
+}
+
+type RaceTable struct {
+	Season string `json:"season"`
+	Round  string `json:"round,omitempty"`
+	Races  []Race `json:"Races"`
+}
+
+type DriverTable struct {
+	Season  string   `json:"season"`
+	Drivers []Driver `json:"Drivers"`
+}
+
+type ConstructorTable struct {
+	Season       string        `json:"season"`
+	Constructors []Constructor `json:"Constructors"`
+}
+
+type CircuitTable struct {
+	Season   string    `json:"season,omitempty"`
+	Circuits []Circuit `json:"Circuits"`
+}
+
+type SeasonTable struct {
+	Seasons []Season `json:"Seasons"`
+}
+
+// Season — строка списка сезонов; URL ведёт на статью о чемпионате
+type Season struct {
+	Season string `json:"season"`
+	URL    string `json:"url"`
+}
+
+type StandingsTable struct {
+	Season         string          `json:"season"`
+	Round          string          `json:"round,omitempty"`
+	StandingsLists []StandingsList `json:"StandingsLists"`
+}
+
+// StandingsList — положение в чемпионате после раунда Round
+type StandingsList struct {
+	Season               string                `json:"season"`
+	Round                string                `json:"round"`
+	DriverStandings      []DriverStanding      `json:"DriverStandings,omitempty"`
+	ConstructorStandings []ConstructorStanding `json:"ConstructorStandings,omitempty"`
+}
+
+type Circuit struct {
+	CircuitID   string   `json:"circuitId"`
+	CircuitName string   `json:"circuitName"`
+	URL         string   `json:"url"`
+	Location    Location `json:"Location"`
+}
+
+type Location struct {
+	Lat      string `json:"lat"`
+	Long     string `json:"long"`
+	Locality string `json:"locality"`
+	Country  string `json:"country"`
+}
+
+type Session struct {
+	Date string `json:"date"`
+	Time string `json:"time"`
+}
+
+// Start возвращает начало сессии в UTC. Если время не указано (старые сезоны),
+// берётся полночь. ok == false, если дата пустая или не разбирается
+func (s Session) Start() (start time.Time, ok bool) {
+	if s.Date == "" {
+		return time.Time{}, false
+	}
+	if s.Time != "" {
+		if start, err := time.Parse("2006-01-02 15:04:05Z07:00", s.Date+" "+s.Time); err == nil {
+			return start, true
+		}
+	}
+	start, err := time.Parse("2006-01-02", s.Date)
+	return start, err == nil
+}
+
+type Driver struct {
+	DriverID        string `json:"driverId"`
+	PermanentNumber string `json:"permanentNumber"`
+	Code            string `json:"code"`
+	URL             string `json:"url"`
+	GivenName       string `json:"givenName"`
+	FamilyName      string `json:"familyName"`
+	DateOfBirth     string `json:"dateOfBirth"`
+	Nationality     string `json:"nationality"`
+}
+
+type Constructor struct {
+	ConstructorID string `json:"constructorId"`
+	URL           string `json:"url"`
+	Name          string `json:"name"`
+	Nationality   string `json:"nationality"`
+}
+
+type Race struct {
+	Season            string             `json:"season,omitempty"`
+	Round             string             `json:"round"`
+	RaceName          string             `json:"raceName"`
+	Date              string             `json:"date"`
+	Time              string             `json:"time,omitempty"`
+	Circuit           Circuit            `json:"Circuit"`
+	URL               string             `json:"url"`
+	FirstPractice     Session            `json:"FirstPractice"`
+	SecondPractice    Session            `json:"SecondPractice"`
+	ThirdPractice     *Session           `json:"ThirdPractice,omitempty"`
+	Qualifying        Session            `json:"Qualifying"`
+	Sprint            *Session           `json:"Sprint,omitempty"`
+	SprintQualifying  *Session           `json:"SprintQualifying,omitempty"`  // квалификация к спринту с 2024 года
+	SprintShootout    *Session           `json:"SprintShootout,omitempty"`    // то же в 2023 году
+	Results           []RaceResult       `json:"Results,omitempty"`           // заполняется только в ответе /{year}/{round}/results
+	QualifyingResults []QualifyingResult `json:"QualifyingResults,omitempty"` // и /{year}/{round}/qualifying
+	SprintResults     []SprintResult     `json:"SprintResults,omitempty"`     // и /{year}/{round}/sprint
+	Laps              []Lap              `json:"Laps,omitempty"`              // и /{year}/{round}/laps
+	PitStops          []PitStop          `json:"PitStops,omitempty"`          // и /{year}/{round}/pitstops
+}
+
+// Start — начало самой гонки
+func (r Race) Start() (time.Time, bool) {
+	return Session{Date: r.Date, Time: r.Time}.Start()
+}
+
+// HasTime — известно ли время начала, а не только дата
+func (s Session) HasTime() bool {
+	return s.Time != ""
+}
+
+// NamedSession — сессия этапа с названием и разобранным временем начала
+type NamedSession struct {
+	Name    string
+	Start   time.Time // в UTC
+	HasTime bool      // false — в данных только дата (старые сезоны)
+}
+
+// Sessions — все сессии этапа вместе с гонкой в порядке начала
+// (на спринт-уикендах порядок менялся от сезона к сезону). Сессии без даты пропускаются
+func (r Race) Sessions() []NamedSession {
+	sessions := []struct {
+		name    string
+		session *Session
+	}{
+		{"First Practice", &r.FirstPractice},
+		{"Second Practice", &r.SecondPractice},
+		{"Third Practice", r.ThirdPractice},
+		{"Qualifying", &r.Qualifying},
+		{"Sprint Shootout", r.SprintShootout},
+		{"Sprint Qualifying", r.SprintQualifying},
+		{"Sprint", r.Sprint},
+		{"Race", &Session{Date: r.Date, Time: r.Time}},
+	}
+	var named []NamedSession
+	for _, s := range sessions {
+		if s.session == nil {
+			continue
+		}
+		if start, ok := s.session.Start(); ok {
+			named = append(named, NamedSession{Name: s.name, Start: start, HasTime: s.session.HasTime()})
+		}
+	}
+	sort.SliceStable(named, func(i, j int) bool { return named[i].Start.Before(named[j].Start) })
+	return named
+}
+
+type RaceResult struct {
+	Number      string      `json:"number"`
+	Position    string      `json:"position"`
+	Points      string      `json:"points"`
+	Driver      Driver      `json:"Driver"`
+	Constructor Constructor `json:"Constructor"`
+	Grid        string      `json:"grid"`
+	Laps        string      `json:"laps"`
+	Status      string      `json:"status"`
+	Time        *struct {   //анонимная структура
+		Millis string `json:"millis"`
+		Time   string `json:"time"`
+	} `json:"Time,omitempty"` //если Time == nil, то при сериализации в JSON это поле будет пропущено
+	FastestLap *FastestLap `json:"FastestLap,omitempty"` // есть с 2004 года
+}
+
+// FastestLap — лучший круг пилота в гонке; Rank == "1" — быстрейший круг гонки
+type FastestLap struct {
+	Rank string `json:"rank"`
+	Lap  string `json:"lap"`
+	Time struct {
+		Time string `json:"time"`
+	} `json:"Time"`
+	AverageSpeed struct {
+		Units string `json:"units"` // "kph"
+		Speed string `json:"speed"`
+	} `json:"AverageSpeed"`
+}
+
+// SprintResult — строка классификации спринта, поля те же, что у гонки
+type SprintResult RaceResult
+
+type QualifyingResult struct {
+	Number      string      `json:"number"`
+	Position    string      `json:"position"`
+	Driver      Driver      `json:"Driver"`
+	Constructor Constructor `json:"Constructor"`
+	Q1          string      `json:"Q1"`
+	Q2          string      `json:"Q2,omitempty"` // пусто, если пилот выбыл в Q1
+	Q3          string      `json:"Q3,omitempty"`
+}
+
+// Lap — позиции и время всех пилотов на одном круге
+type Lap struct {
+	Number  string   `json:"number"`
+	Timings []Timing `json:"Timings"`
+}
+
+type Timing struct {
+	DriverID string `json:"driverId"`
+	Position string `json:"position"`
+	Time     string `json:"time"` // "1:32.123"
+}
+
+// PitStop — один пит-стоп; данные есть с 2011 года
+type PitStop struct {
+	DriverID string `json:"driverId"`
+	Lap      string `json:"lap"`
+	Stop     string `json:"stop"`     // номер остановки пилота в гонке
+	Time     string `json:"time"`     // местное время суток, "14:05:31"
+	Duration string `json:"duration"` // время в пит-лейне, "21.123"; при красном флаге бывает "1:02:13.456"
+}
+
+type DriverStanding struct {
+	Position     string        `json:"position"`
+	PositionText string        `json:"positionText"`
+	Points       string        `json:"points"`
+	Wins         string        `json:"wins"`
+	Driver       Driver        `json:"Driver"`
+	Constructors []Constructor `json:"Constructors"` // все команды пилота за сезон
+}
+
+type ConstructorStanding struct {
+	Position     string      `json:"position"`
+	PositionText string      `json:"positionText"`
+	Points       string      `json:"points"`
+	Wins         string      `json:"wins"`
+	Constructor  Constructor `json:"Constructor"`
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// Экспорт сессий в iCalendar (RFC 5545) — для подписки календарей на гоночные уикенды
+
+// sessionDurations — примерная длительность сессий: у Ergast есть только время начала
+var sessionDurations = map[string]time.Duration{
+	"First Practice":    time.Hour,
+	"Second Practice":   time.Hour,
+	"Third Practice":    time.Hour,
+	"Qualifying":        time.Hour,
+	"Sprint Shootout":   45 * time.Minute,
+	"Sprint Qualifying": 45 * time.Minute,
+	"Sprint":            time.Hour,
+	"Race":              2 * time.Hour,
+}
+
+const icsTimeLayout = "20060102T150405Z"
+
+// writeICS пишет календарь со всеми сессиями гонок races сезона season.
+// now попадает в DTSTAMP каждого события
+func writeICS(w io.Writer, season string, races []ergast.Race, now time.Time) error {
+	var b strings.Builder
+	line := func(name, value string) { writeICSLine(&b, name+":"+value) }
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//F1 Race Catalog//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeICS(fmt.Sprintf("Formula 1 %s", season)))
+	for _, race := range races {
+		location := escapeICS(fmt.Sprintf("%s, %s, %s",
+			race.Circuit.CircuitName, race.Circuit.Location.Locality, race.Circuit.Location.Country))
+		for _, session := range race.Sessions() {
+			line("BEGIN", "VEVENT")
+			// UID не меняется между выгрузками, поэтому календарь обновляет событие, а не дублирует его
+			line("UID", fmt.Sprintf("%s-%s-%s@f1catalog", season, race.Round, strings.ToLower(strings.ReplaceAll(session.Name, " ", "-"))))
+			line("DTSTAMP", now.UTC().Format(icsTimeLayout))
+			if session.HasTime {
+				line("DTSTART", session.Start.UTC().Format(icsTimeLayout))
+				line("DTEND", session.Start.Add(sessionDurations[session.Name]).UTC().Format(icsTimeLayout))
+			} else { // известна только дата — событие на весь день
+				line("DTSTART;VALUE=DATE", session.Start.Format("20060102"))
+				line("DTEND;VALUE=DATE", session.Start.AddDate(0, 0, 1).Format("20060102"))
+			}
+			line("SUMMARY", escapeICS(fmt.Sprintf("%s — %s", race.RaceName, session.Name)))
+			line("LOCATION", location)
+			if race.Circuit.Location.Lat != "" && race.Circuit.Location.Long != "" {
+				line("GEO", race.Circuit.Location.Lat+";"+race.Circuit.Location.Long)
+			}
+			if race.URL != "" {
+				line("URL", race.URL)
+			}
+			line("DESCRIPTION", escapeICS(fmt.Sprintf("Round %s of the %s Formula 1 season.", race.Round, season)))
+			line("END", "VEVENT")
+		}
+	}
+	line("END", "VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeICS экранирует текстовое значение по RFC 5545
+func escapeICS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeICSLine пишет строку с CRLF, перенося её каждые 75 байт (продолжение начинается с пробела)
+// и не разрывая символы UTF-8
+func writeICSLine(b *strings.Builder, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // пробел в начале продолжения тоже считается
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+// exportCalendar сохраняет сессии races сезона season в .ics через диалог сохранения файла
+func (v *SeasonView) exportCalendar(season, fileName string, races []ergast.Race) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		if writer == nil {
+			return // диалог закрыли без выбора файла
+		}
+		err = writeICS(writer, season, races, time.Now())
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("saving calendar: %w", err), v.window)
+			return
+		}
+		v.statusTextForDataView.Set("Calendar saved to " + writer.URI().Path())
+	}, v.window)
+	save.SetFileName(fileName)
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".ics"}))
+	save.Show()
+}
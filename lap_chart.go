@@ -0,0 +1,187 @@
+package main
+
+import (
+	"image/color"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// chartSeries — линия одного пилота: positions[lap] — позиция после круга, 0 — нет данных.
+// positions[0] — место на старте
+type chartSeries struct {
+	label     string
+	color     color.Color
+	positions []int
+}
+
+// positionChart — график "позиция по кругам": по линии на пилота, первая позиция сверху
+type positionChart struct {
+	widget.BaseWidget
+
+	series       []chartSeries
+	laps         int // число кругов (по оси X)
+	maxPositions int // число позиций (по оси Y)
+}
+
+func newPositionChart() *positionChart {
+	chart := &positionChart{}
+	chart.ExtendBaseWidget(chart)
+	return chart
+}
+
+// SetSeries заменяет данные графика. Вызывается в UI-потоке
+func (c *positionChart) SetSeries(series []chartSeries) {
+	c.series = series
+	c.laps, c.maxPositions = 0, 0
+	for _, s := range series {
+		c.laps = max(c.laps, len(s.positions)-1)
+		for _, position := range s.positions {
+			c.maxPositions = max(c.maxPositions, position)
+		}
+	}
+	c.Refresh()
+}
+
+func (c *positionChart) CreateRenderer() fyne.WidgetRenderer {
+	r := &positionChartRenderer{chart: c}
+	r.rebuild()
+	return r
+}
+
+// chartSegment — отрезок линии между двумя кругами, координаты в кругах и позициях
+type chartSegment struct {
+	line                   *canvas.Line
+	lap1, pos1, lap2, pos2 int
+}
+
+// chartLabel — подпись, привязанная к точке графика
+type chartLabel struct {
+	text     *canvas.Text
+	lap, pos int
+}
+
+type positionChartRenderer struct {
+	chart    *positionChart
+	segments []chartSegment
+	labels   []chartLabel // подписи осей и коды пилотов
+	objects  []fyne.CanvasObject
+}
+
+// Поля вокруг области графика под подписи осей и пилотов
+const (
+	chartMarginLeft   = 30
+	chartMarginRight  = 60
+	chartMarginTop    = 10
+	chartMarginBottom = 24
+)
+
+func (r *positionChartRenderer) rebuild() {
+	r.segments, r.labels, r.objects = nil, nil, nil
+	c := r.chart
+	if c.laps == 0 || c.maxPositions == 0 {
+		return
+	}
+	textColor := theme.Color(theme.ColorNameForeground)
+
+	for position := 1; position <= c.maxPositions; position++ { // позиции слева
+		text := canvas.NewText(strconv.Itoa(position), textColor)
+		text.TextSize = theme.CaptionTextSize()
+		r.labels = append(r.labels, chartLabel{text: text, lap: -1, pos: position})
+	}
+	for lap := 0; lap <= c.laps; lap += lapTickStep(c.laps) { // круги снизу
+		text := canvas.NewText(strconv.Itoa(lap), textColor)
+		text.TextSize = theme.CaptionTextSize()
+		r.labels = append(r.labels, chartLabel{text: text, lap: lap, pos: c.maxPositions + 1})
+	}
+
+	for _, s := range c.series {
+		prev, last := -1, -1
+		for lap, position := range s.positions {
+			if position == 0 {
+				prev = -1 // сход или пропуск в данных — линия прерывается
+				continue
+			}
+			if prev >= 0 {
+				line := canvas.NewLine(s.color)
+				line.StrokeWidth = 2
+				r.segments = append(r.segments, chartSegment{line: line, lap1: prev, pos1: s.positions[prev], lap2: lap, pos2: position})
+			}
+			prev, last = lap, lap
+		}
+		if last >= 0 {
+			text := canvas.NewText(s.label, s.color)
+			text.TextSize = theme.CaptionTextSize()
+			r.labels = append(r.labels, chartLabel{text: text, lap: last, pos: s.positions[last]})
+		}
+	}
+
+	for _, segment := range r.segments {
+		r.objects = append(r.objects, segment.line)
+	}
+	for _, label := range r.labels {
+		r.objects = append(r.objects, label.text)
+	}
+}
+
+// lapTickStep — шаг подписей кругов, чтобы их было не больше десятка
+func lapTickStep(laps int) int {
+	switch {
+	case laps <= 10:
+		return 1
+	case laps <= 50:
+		return 5
+	default:
+		return 10
+	}
+}
+
+func (r *positionChartRenderer) point(size fyne.Size, lap, pos int) fyne.Position {
+	c := r.chart
+	width := size.Width - chartMarginLeft - chartMarginRight
+	height := size.Height - chartMarginTop - chartMarginBottom
+	x := chartMarginLeft + width*float32(lap)/float32(max(c.laps, 1))
+	y := chartMarginTop + height*float32(pos-1)/float32(max(c.maxPositions-1, 1))
+	return fyne.NewPos(x, y)
+}
+
+func (r *positionChartRenderer) Layout(size fyne.Size) {
+	for _, segment := range r.segments {
+		segment.line.Position1 = r.point(size, segment.lap1, segment.pos1)
+		segment.line.Position2 = r.point(size, segment.lap2, segment.pos2)
+	}
+	for _, label := range r.labels {
+		textSize := label.text.MinSize()
+		label.text.Resize(textSize)
+		switch {
+		case label.lap < 0: // номер позиции у левого края
+			p := r.point(size, 0, label.pos)
+			label.text.Move(fyne.NewPos(2, p.Y-textSize.Height/2))
+		case label.pos > r.chart.maxPositions: // номер круга под осью
+			p := r.point(size, label.lap, r.chart.maxPositions)
+			label.text.Move(fyne.NewPos(p.X-textSize.Width/2, size.Height-textSize.Height))
+		default: // код пилота справа от последней точки линии
+			p := r.point(size, label.lap, label.pos)
+			label.text.Move(fyne.NewPos(p.X+4, p.Y-textSize.Height/2))
+		}
+	}
+}
+
+func (r *positionChartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(400, 300)
+}
+
+func (r *positionChartRenderer) Refresh() {
+	r.rebuild()
+	r.Layout(r.chart.Size())
+	canvas.Refresh(r.chart)
+}
+
+func (r *positionChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *positionChartRenderer) Destroy() {}
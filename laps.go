@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+var lapHeaders = []string{"Lap", "Pos", "Driver", "Team", "Time"}
+
+// Варианты сортировки таблицы кругов
+const (
+	sortLapsByLap      = "Lap"
+	sortLapsByPosition = "Position"
+	sortLapsByDriver   = "Driver"
+	sortLapsByTime     = "Lap Time"
+)
+
+// lapRow — время одного пилота на одном круге
+type lapRow struct {
+	lap      int
+	position int
+	name     string
+	driver   ergast.Driver
+	team     string
+	teamInfo ergast.Constructor
+	time     time.Duration // 0, если время не разобралось
+	timeText string
+}
+
+// lapsPanel — вкладка "Laps": график позиций по кругам и таблица времён кругов
+type lapsPanel struct {
+	view *SeasonView
+
+	chart      *positionChart
+	table      *widget.Table
+	sortBy     *widget.Select
+	descending *widget.Check
+	status     *widget.Label
+
+	rows    []lapRow
+	numCols int
+}
+
+func newLapsPanel(v *SeasonView) *lapsPanel {
+	p := &lapsPanel{view: v, chart: newPositionChart()}
+	p.table = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	p.sortBy = widget.NewSelect([]string{sortLapsByLap, sortLapsByPosition, sortLapsByDriver, sortLapsByTime}, func(string) { p.showRows() })
+	p.sortBy.SetSelected(sortLapsByLap)
+	p.descending = widget.NewCheck("Descending", func(bool) { p.showRows() })
+	p.status = widget.NewLabel("Select a race to see its laps.")
+	return p
+}
+
+func (p *lapsPanel) content() fyne.CanvasObject {
+	sortBar := container.NewHBox(widget.NewLabel("Sort by"), p.sortBy, p.descending, p.status)
+	tablePart := container.NewBorder(sortBar, nil, nil, nil, container.NewVScroll(p.table))
+	split := container.NewVSplit(p.chart, tablePart)
+	split.SetOffset(0.6)
+	return split
+}
+
+func (p *lapsPanel) reset(status string) {
+	p.rows = nil
+	p.numCols = 0
+	p.chart.SetSeries(nil)
+	resetTable(p.table)
+	p.status.SetText(status)
+}
+
+// load загружает все страницы кругов гонки и её результаты (для цветов команд и стартовой решётки)
+func (p *lapsPanel) load(ctx context.Context, year, round string) {
+	p.reset("Loading laps...")
+	go func() {
+		laps, err := p.view.app.client.Laps(ctx, year, round)
+		var results []ergast.RaceResult
+		if err == nil {
+			results, err = p.view.app.client.Results(ctx, year, round)
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Println("Error loading laps:", err)
+				p.reset("Error loading laps: " + err.Error())
+				return
+			}
+			if len(laps) == 0 {
+				p.reset("No lap data for this race (available from 1996).")
+				return
+			}
+			p.show(laps, results)
+		})
+	}()
+}
+
+func (p *lapsPanel) show(laps []ergast.Lap, results []ergast.RaceResult) {
+	byDriver := make(map[string]ergast.RaceResult, len(results))
+	for _, result := range results {
+		byDriver[result.Driver.DriverID] = result
+	}
+
+	// Линии графика — в порядке финиша, чтобы легенда справа читалась сверху вниз
+	series := make([]chartSeries, 0, len(results))
+	seriesIndex := make(map[string]int, len(results))
+	teamDrivers := make(map[string]int)
+	for _, result := range results {
+		color := teamColor(result.Constructor.ConstructorID)
+		if teamDrivers[result.Constructor.ConstructorID] > 0 {
+			color = secondDriverColor(color)
+		}
+		teamDrivers[result.Constructor.ConstructorID]++
+		positions := make([]int, len(laps)+1)
+		positions[0], _ = strconv.Atoi(result.Grid) // 0 — старт с пит-лейна
+		seriesIndex[result.Driver.DriverID] = len(series)
+		series = append(series, chartSeries{label: driverCode(result.Driver), color: color, positions: positions})
+	}
+
+	p.rows = p.rows[:0]
+	for _, lap := range laps {
+		number, _ := strconv.Atoi(lap.Number)
+		for _, timing := range lap.Timings {
+			position, _ := strconv.Atoi(timing.Position)
+			if i, ok := seriesIndex[timing.DriverID]; ok && number > 0 && number < len(series[i].positions) {
+				series[i].positions[number] = position
+			}
+			row := lapRow{lap: number, position: position, name: timing.DriverID, timeText: timing.Time}
+			row.driver.DriverID = timing.DriverID
+			if result, ok := byDriver[timing.DriverID]; ok {
+				row.name = driverName(result.Driver)
+				row.driver = result.Driver
+				row.team = result.Constructor.Name
+				row.teamInfo = result.Constructor
+			}
+			row.time, _ = ergast.ParseLapTime(timing.Time)
+			p.rows = append(p.rows, row)
+		}
+	}
+
+	p.chart.SetSeries(series)
+	p.status.SetText(fmt.Sprintf("%d laps", len(laps)))
+	p.showRows()
+}
+
+// showRows сортирует строки по выбранной колонке и перерисовывает таблицу
+func (p *lapsPanel) showRows() {
+	if len(p.rows) == 0 {
+		return
+	}
+	sortLapRows(p.rows, p.sortBy.Selected, p.descending.Checked)
+	rows := p.rows
+	p.numCols = len(lapHeaders)
+	numCols := p.numCols
+	p.table.Length = func() (int, int) { return len(rows) + 1, numCols }
+	p.table.UpdateCell = func(id widget.TableCellID, cell fyne.CanvasObject) {
+		label := cell.(*widget.Label)
+		label.Alignment = fyne.TextAlignLeading
+		if id.Row == 0 {
+			label.Alignment = fyne.TextAlignCenter
+			if id.Col < len(lapHeaders) {
+				label.SetText(lapHeaders[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+			}
+			return
+		}
+		label.TextStyle = fyne.TextStyle{}
+		rowIndex := id.Row - 1
+		if rowIndex >= len(rows) {
+			return
+		}
+		row := rows[rowIndex]
+		if cells := []string{strconv.Itoa(row.lap), strconv.Itoa(row.position), row.name, row.team, row.timeText}; id.Col < len(cells) {
+			label.SetText(cells[id.Col])
+		}
+	}
+	p.table.Refresh()
+	p.view.app.linkProfiles(p.table,
+		rowDrivers(rows, func(r lapRow) ergast.Driver { return r.driver }),
+		rowConstructors(rows, lapHeaders, func(r lapRow) ergast.Constructor { return r.teamInfo }))
+	p.view.resizeTableColumnsEqually(p.table, p.numCols)
+}
+
+// sortLapRows сортирует круги; при равенстве — по кругу и позиции, чтобы порядок был стабильным
+func sortLapRows(rows []lapRow, by string, descending bool) {
+	less := func(a, b lapRow) bool {
+		switch by {
+		case sortLapsByPosition:
+			if a.position != b.position {
+				return a.position < b.position
+			}
+		case sortLapsByDriver:
+			if a.name != b.name {
+				return a.name < b.name
+			}
+		case sortLapsByTime:
+			if a.time != b.time {
+				return a.time < b.time
+			}
+		}
+		if a.lap != b.lap {
+			return a.lap < b.lap
+		}
+		return a.position < b.position
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if descending {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+}
+
+// driverCode — трёхбуквенный код пилота; у старых сезонов его нет, тогда фамилия
+func driverCode(driver ergast.Driver) string {
+	if driver.Code != "" {
+		return driver.Code
+	}
+	return strings.ToUpper(driver.FamilyName)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// Ячейка таблицы → пилот или команда. Номер строки передаётся без заголовка
+type (
+	driverAt      func(row, col int) (ergast.Driver, bool)
+	constructorAt func(row, col int) (ergast.Constructor, bool)
+)
+
+// linkProfiles открывает профиль по клику на ячейку таблицы: команды, если
+// constructor её узнаёт, иначе пилота. Любая из функций может быть nil
+func (a *App) linkProfiles(table *widget.Table, driver driverAt, constructor constructorAt) {
+	table.OnSelected = func(id widget.TableCellID) {
+		table.UnselectAll() // выделение не нужно — ячейка работает как ссылка
+		if id.Row == 0 {
+			return
+		}
+		if constructor != nil {
+			if c, ok := constructor(id.Row-1, id.Col); ok && c.ConstructorID != "" {
+				a.openConstructorWindow(c)
+				return
+			}
+		}
+		if driver != nil {
+			if d, ok := driver(id.Row-1, id.Col); ok && d.DriverID != "" {
+				a.openDriverWindow(d)
+			}
+		}
+	}
+}
+
+// rowDrivers — пилот строки, для таблиц, где в каждой строке один пилот
+func rowDrivers[T any](items []T, driver func(T) ergast.Driver) driverAt {
+	return func(row, _ int) (ergast.Driver, bool) {
+		if row < 0 || row >= len(items) {
+			return ergast.Driver{}, false
+		}
+		return driver(items[row]), true
+	}
+}
+
+// rowConstructors — команда строки; если в headers есть колонка "Team", то только по клику на неё
+func rowConstructors[T any](items []T, headers []string, constructor func(T) ergast.Constructor) constructorAt {
+	teamCol := slices.Index(headers, "Team")
+	return func(row, col int) (ergast.Constructor, bool) {
+		if row < 0 || row >= len(items) || (teamCol >= 0 && col != teamCol) {
+			return ergast.Constructor{}, false
+		}
+		return constructor(items[row]), true
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+var pitStopHeaders = []string{"Driver", "Team", "Stops", "Stop", "Lap", "Duration"}
+
+var pitStopSeasonHeaders = []string{"Team", "Stops", "Average", "Median", "Fastest"}
+
+// Режимы вкладки пит-стопов
+const (
+	pitStopsForRace   = "Selected Race"
+	pitStopsForSeason = "Season by Team"
+)
+
+// maxPitStopDuration — всё дольше считается ожиданием в пит-лейне под красным флагом
+// и в сравнение команд не идёт
+const maxPitStopDuration = 2 * time.Minute
+
+// pitStopsPanel — вкладка "Pit Stops": стопы и стинты выбранной гонки
+// либо сравнение длительности стопов по командам за сезон
+type pitStopsPanel struct {
+	view *SeasonView
+
+	mode   *widget.RadioGroup
+	status *widget.Label
+
+	stints       *stintChart
+	stintsScroll *container.Scroll
+	raceTable    *widget.Table
+	raceContent  fyne.CanvasObject
+
+	seasonTable   *widget.Table
+	seasonContent fyne.CanvasObject
+	seasonLoaded  string             // сезон, для которого посчитана сводка
+	cancelSeason  context.CancelFunc // отменяет подсчёт сводки
+
+	statuses      map[string]string // строка состояния для каждого режима
+	numColsRace   int
+	numColsSeason int
+}
+
+func newPitStopsPanel(v *SeasonView) *pitStopsPanel {
+	p := &pitStopsPanel{view: v, stints: newStintChart(), status: widget.NewLabel(""), statuses: make(map[string]string)}
+	p.raceTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	p.seasonTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	p.stintsScroll = container.NewVScroll(p.stints)
+	split := container.NewVSplit(p.stintsScroll, container.NewVScroll(p.raceTable))
+	split.SetOffset(0.5)
+	p.raceContent = split
+	p.seasonContent = container.NewVScroll(p.seasonTable)
+	p.seasonContent.Hide()
+
+	p.mode = widget.NewRadioGroup([]string{pitStopsForRace, pitStopsForSeason}, func(mode string) { p.showMode(mode) })
+	p.mode.Horizontal = true
+	p.mode.Required = true
+	p.mode.SetSelected(pitStopsForRace)
+	return p
+}
+
+func (p *pitStopsPanel) content() fyne.CanvasObject {
+	top := container.NewHBox(p.mode, p.status)
+	return container.NewBorder(top, nil, nil, nil, container.NewStack(p.raceContent, p.seasonContent))
+}
+
+// showMode переключает вкладку между гонкой и сезоном; сводку по сезону считает при первом открытии
+func (p *pitStopsPanel) showMode(mode string) {
+	if mode == pitStopsForSeason {
+		p.raceContent.Hide()
+		p.seasonContent.Show()
+		if p.seasonLoaded != p.view.loadedSeason {
+			p.loadSeason()
+		}
+	} else {
+		p.seasonContent.Hide()
+		p.raceContent.Show()
+	}
+	p.status.SetText(p.statuses[mode])
+}
+
+// setStatus запоминает состояние режима mode и показывает его, если этот режим открыт
+func (p *pitStopsPanel) setStatus(mode, status string) {
+	p.statuses[mode] = status
+	if p.mode.Selected == mode {
+		p.status.SetText(status)
+	}
+}
+
+// reset очищает данные гонки; forSeason — ещё и сводку сезона (при смене сезона)
+func (p *pitStopsPanel) reset(status string, forSeason bool) {
+	p.stints.SetRows(nil)
+	resetTable(p.raceTable)
+	p.numColsRace = 0
+	p.setStatus(pitStopsForRace, status)
+	if forSeason {
+		if p.cancelSeason != nil {
+			p.cancelSeason()
+		}
+		p.seasonLoaded = ""
+		p.statuses[pitStopsForSeason] = ""
+		resetTable(p.seasonTable)
+		p.numColsSeason = 0
+		p.mode.SetSelected(pitStopsForRace)
+	}
+}
+
+// load загружает стопы выбранной гонки и её результаты (команды, порядок финиша, число кругов)
+func (p *pitStopsPanel) load(ctx context.Context, year, round string) {
+	p.reset("Loading pit stops...", false)
+	go func() {
+		stops, err := p.view.app.client.PitStops(ctx, year, round)
+		var results []ergast.RaceResult
+		if err == nil {
+			results, err = p.view.app.client.Results(ctx, year, round)
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Println("Error loading pit stops:", err)
+				p.reset("Error loading pit stops: "+err.Error(), false)
+				return
+			}
+			if len(stops) == 0 {
+				p.reset("No pit stop data for this race (available from 2011).", false)
+				return
+			}
+			p.showRace(stops, results)
+		})
+	}()
+}
+
+func (p *pitStopsPanel) showRace(stops []ergast.PitStop, results []ergast.RaceResult) {
+	byDriver := make(map[string][]ergast.PitStop)
+	for _, stop := range stops {
+		byDriver[stop.DriverID] = append(byDriver[stop.DriverID], stop)
+	}
+
+	var rows [][]string
+	var drivers []ergast.Driver           // пилот каждой строки таблицы
+	var constructors []ergast.Constructor // и его команда
+	var stintRows []stintRow
+	for _, result := range results { // в порядке финиша
+		driverStops := byDriver[result.Driver.DriverID]
+		laps, _ := strconv.Atoi(result.Laps)
+		stintRows = append(stintRows, stintRow{
+			label:  driverCode(result.Driver),
+			color:  teamColor(result.Constructor.ConstructorID),
+			stints: stintsFromStops(driverStops, laps),
+		})
+		for _, stop := range driverStops {
+			rows = append(rows, []string{
+				driverName(result.Driver),
+				result.Constructor.Name,
+				strconv.Itoa(len(driverStops)),
+				stop.Stop,
+				stop.Lap,
+				stop.Duration,
+			})
+			drivers = append(drivers, result.Driver)
+			constructors = append(constructors, result.Constructor)
+		}
+	}
+
+	p.stints.SetRows(stintRows)
+	p.stintsScroll.Refresh() // высота диаграммы зависит от числа пилотов
+	p.numColsRace = showRows(p.raceTable, pitStopHeaders, rows)
+	p.view.app.linkProfiles(p.raceTable,
+		rowDrivers(drivers, func(d ergast.Driver) ergast.Driver { return d }),
+		rowConstructors(constructors, pitStopHeaders, func(c ergast.Constructor) ergast.Constructor { return c }))
+	p.view.resizeTableColumnsEqually(p.raceTable, p.numColsRace)
+	p.setStatus(pitStopsForRace, fmt.Sprintf("%d pit stops", len(stops)))
+}
+
+// stintsFromStops делит гонку пилота на отрезки между кругами пит-стопов
+func stintsFromStops(stops []ergast.PitStop, laps int) [][2]int {
+	var stints [][2]int
+	from := 0
+	for _, stop := range stops {
+		lap, err := strconv.Atoi(stop.Lap)
+		if err != nil || lap <= from {
+			continue
+		}
+		stints = append(stints, [2]int{from, lap})
+		from = lap
+	}
+	if laps > from {
+		stints = append(stints, [2]int{from, laps})
+	}
+	return stints
+}
+
+// loadSeason собирает стопы всех проведённых гонок сезона и сравнивает команды
+func (p *pitStopsPanel) loadSeason() {
+	if p.cancelSeason != nil {
+		p.cancelSeason()
+	}
+	resetTable(p.seasonTable)
+	p.numColsSeason = 0
+	races := p.view.completedRaces
+	year := p.view.loadedSeason
+	if p.view.seasonCtx == nil || len(races) == 0 {
+		p.setStatus(pitStopsForSeason, "No completed races in this season.")
+		return
+	}
+	ctx, cancel := context.WithCancel(p.view.seasonCtx)
+	p.cancelSeason = cancel
+	p.setStatus(pitStopsForSeason, "Loading pit stops of the season...")
+
+	go func() {
+		durations := make(map[string][]time.Duration) // по constructorId
+		constructors := make(map[string]ergast.Constructor)
+		for i, race := range races {
+			fyne.Do(func() {
+				if ctx.Err() == nil {
+					p.setStatus(pitStopsForSeason, fmt.Sprintf("Loading pit stops: round %d of %d...", i+1, len(races)))
+				}
+			})
+			stops, err := p.view.app.client.PitStops(ctx, year, race.Round)
+			var results []ergast.RaceResult
+			if err == nil {
+				results, err = p.view.app.client.Results(ctx, year, race.Round)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Println("Error loading pit stops for round", race.Round+":", err)
+				continue // одна недоступная гонка не должна ломать всю сводку
+			}
+			teams := make(map[string]ergast.Constructor, len(results))
+			for _, result := range results {
+				teams[result.Driver.DriverID] = result.Constructor
+				constructors[result.Constructor.ConstructorID] = result.Constructor
+			}
+			for _, stop := range stops {
+				d, ok := ergast.ParseLapTime(stop.Duration)
+				if team := teams[stop.DriverID].ConstructorID; ok && team != "" && d <= maxPitStopDuration {
+					durations[team] = append(durations[team], d)
+				}
+			}
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			p.showSeason(year, durations, constructors)
+		})
+	}()
+}
+
+func (p *pitStopsPanel) showSeason(year string, durations map[string][]time.Duration, constructors map[string]ergast.Constructor) {
+	type teamSummary struct {
+		team                     ergast.Constructor
+		stops                    int
+		average, median, fastest time.Duration
+	}
+	summaries := make([]teamSummary, 0, len(durations))
+	for team, stops := range durations {
+		sort.Slice(stops, func(i, j int) bool { return stops[i] < stops[j] })
+		var total time.Duration
+		for _, d := range stops {
+			total += d
+		}
+		summaries = append(summaries, teamSummary{
+			team:    constructors[team],
+			stops:   len(stops),
+			average: total / time.Duration(len(stops)),
+			median:  stops[len(stops)/2],
+			fastest: stops[0],
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].median < summaries[j].median })
+
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		rows = append(rows, []string{s.team.Name, strconv.Itoa(s.stops), formatSeconds(s.average), formatSeconds(s.median), formatSeconds(s.fastest)})
+	}
+	p.seasonLoaded = year
+	p.numColsSeason = showRows(p.seasonTable, pitStopSeasonHeaders, rows)
+	p.view.app.linkProfiles(p.seasonTable, nil, rowConstructors(summaries, pitStopSeasonHeaders, func(s teamSummary) ergast.Constructor { return s.team }))
+	p.view.resizeTableColumnsEqually(p.seasonTable, p.numColsSeason)
+	if len(rows) == 0 {
+		p.setStatus(pitStopsForSeason, "No pit stop data for this season (available from 2011).")
+		return
+	}
+	p.setStatus(pitStopsForSeason, fmt.Sprintf("Pit lane time by team, season %s (sorted by median)", year))
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f s", d.Seconds())
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+var qualifyingHeaders = []string{"Pos", "No", "Driver", "Team", "Q1", "Q2", "Q3", "Gap to Pole", "Eliminated In"}
+
+// qualifyingRows считает отставание от поула и сессию, в которой пилот выбыл.
+// До 2006 года квалификация была одной сессией — тогда колонка выбывания пустая
+func qualifyingRows(results []ergast.QualifyingResult) [][]string {
+	knockout, hasQ3 := false, false
+	for _, result := range results {
+		knockout = knockout || result.Q2 != ""
+		hasQ3 = hasQ3 || result.Q3 != ""
+	}
+
+	var pole ergast.QualifyingResult
+	if len(results) > 0 {
+		pole = results[0]
+	}
+	poleTime, hasPole := bestQualifyingLap(pole)
+
+	rows := make([][]string, 0, len(results))
+	for i, result := range results {
+		gap := ""
+		if best, ok := bestQualifyingLap(result); ok && hasPole && i > 0 {
+			gap = fmt.Sprintf("+%.3f", (best - poleTime).Seconds())
+		}
+		eliminated := ""
+		switch {
+		case !knockout:
+		case result.Q3 != "" || (!hasQ3 && result.Q2 != ""):
+			eliminated = "—" // дошёл до финальной сессии
+		case result.Q2 != "":
+			eliminated = "Q2"
+		default:
+			eliminated = "Q1"
+		}
+		rows = append(rows, []string{
+			result.Position,
+			result.Number,
+			driverName(result.Driver),
+			result.Constructor.Name,
+			result.Q1,
+			result.Q2,
+			result.Q3,
+			gap,
+			eliminated,
+		})
+	}
+	return rows
+}
+
+// bestQualifyingLap — лучший круг пилота по всем сессиям квалификации
+func bestQualifyingLap(result ergast.QualifyingResult) (best time.Duration, ok bool) {
+	for _, lap := range []string{result.Q1, result.Q2, result.Q3} {
+		if d, parsed := ergast.ParseLapTime(lap); parsed && (!ok || d < best) {
+			best, ok = d, true
+		}
+	}
+	return best, ok
+}
+
+func (v *SeasonView) loadQualifying(ctx context.Context, year, round string, table *widget.Table) {
+	resetTable(table)
+	v.numColsQualifying = 0
+	if year == "" || round == "" {
+		return
+	}
+	go func() {
+		results, err := v.app.client.Qualifying(ctx, year, round)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Println("Error loading qualifying:", err)
+				return
+			}
+			v.showQualifying(results, table)
+		})
+	}()
+}
+
+// showQualifying выводит квалификацию; если она ещё не прошла или данных нет
+// (до 1994 года Ergast их не хранит), таблица остаётся пустой
+func (v *SeasonView) showQualifying(results []ergast.QualifyingResult, table *widget.Table) {
+	v.numColsQualifying = showRows(table, qualifyingHeaders, qualifyingRows(results))
+	v.app.linkProfiles(table,
+		rowDrivers(results, func(r ergast.QualifyingResult) ergast.Driver { return r.Driver }),
+		rowConstructors(results, qualifyingHeaders, func(r ergast.QualifyingResult) ergast.Constructor { return r.Constructor }))
+	v.resizeTableColumnsEqually(table, v.numColsQualifying)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"F1_catalog/ergast"
+)
+
+// Заголовки и строки таблиц — общие для вкладок GUI и вывода командной строки
+
+var raceHeaders = []string{"Round", "Race", "Date", "Circuit", "Location"}
+
+func raceRow(race ergast.Race) []string {
+	return []string{
+		race.Round,
+		race.RaceName,
+		race.Date,
+		race.Circuit.CircuitName,
+		fmt.Sprintf("%s, %s", race.Circuit.Location.Locality, race.Circuit.Location.Country),
+	}
+}
+
+var raceResultHeaders = []string{"Pos", "No", "Driver", "Team", "Laps", "Time/Retired", "Status", "Points",
+	"Fastest Lap", "On Lap", "FL Rank", "Avg Speed"}
+
+func raceResultRow(result ergast.RaceResult) []string {
+	timeOrStatus := result.Status // у сошедших и круговых времени нет — пишем статус
+	if result.Time != nil && result.Time.Time != "" {
+		timeOrStatus = result.Time.Time
+	}
+	row := []string{
+		result.Position,
+		result.Number,
+		driverName(result.Driver),
+		result.Constructor.Name,
+		result.Laps,
+		timeOrStatus,
+		result.Status,
+		result.Points,
+		"", "", "", "",
+	}
+	if fastest := result.FastestLap; fastest != nil { // до 2004 года Ergast быстрые круги не хранит
+		row[8], row[9], row[10] = fastest.Time.Time, fastest.Lap, fastest.Rank
+		if fastest.AverageSpeed.Speed != "" {
+			row[11] = fastest.AverageSpeed.Speed + " " + fastest.AverageSpeed.Units
+		}
+	}
+	return row
+}
+
+// hasFastestLap — результат с быстрейшим кругом гонки
+func hasFastestLap(result ergast.RaceResult) bool {
+	return result.FastestLap != nil && result.FastestLap.Rank == "1"
+}
+
+var driverHeaders = []string{"Name", "Code", "Number", "Nationality", "DOB"}
+
+func driverRow(driver ergast.Driver) []string {
+	return []string{driverName(driver), driver.Code, driver.PermanentNumber, driver.Nationality, driver.DateOfBirth}
+}
+
+var constructorHeaders = []string{"Name", "Nationality"}
+
+func constructorRow(constructor ergast.Constructor) []string {
+	return []string{constructor.Name, constructor.Nationality}
+}
+
+func driverName(driver ergast.Driver) string {
+	return fmt.Sprintf("%s %s", driver.GivenName, driver.FamilyName)
+}
+
+var driverStandingHeaders = []string{"Pos", "Driver", "Team", "Points", "Wins"}
+
+func driverStandingRow(standing ergast.DriverStanding) []string {
+	teams := make([]string, 0, len(standing.Constructors))
+	for _, constructor := range standing.Constructors { // пилоты, сменившие команду по ходу сезона
+		teams = append(teams, constructor.Name)
+	}
+	return []string{standing.PositionText, driverName(standing.Driver), strings.Join(teams, ", "), standing.Points, standing.Wins}
+}
+
+var constructorStandingHeaders = []string{"Pos", "Team", "Nationality", "Points", "Wins"}
+
+func constructorStandingRow(standing ergast.ConstructorStanding) []string {
+	return []string{standing.PositionText, standing.Constructor.Name, standing.Constructor.Nationality, standing.Points, standing.Wins}
+}
+
+var circuitHeaders = []string{"Circuit", "Locality", "Country"}
+
+func circuitRow(circuit ergast.Circuit) []string {
+	return []string{circuit.CircuitName, circuit.Location.Locality, circuit.Location.Country}
+}
+
+var circuitRaceHeaders = []string{"Season", "Round", "Race", "Date", "Winner", "Team", "Pole"}
+
+// circuitRaceRow — строка истории трассы: в Results и QualifyingResults лежат только первые места
+func circuitRaceRow(race ergast.Race) []string {
+	winner, team, pole := "", "", ""
+	if len(race.Results) > 0 {
+		winner = driverName(race.Results[0].Driver)
+		team = race.Results[0].Constructor.Name
+	}
+	if len(race.QualifyingResults) > 0 {
+		pole = driverName(race.QualifyingResults[0].Driver)
+	}
+	return []string{race.Season, race.Round, race.RaceName, race.Date, winner, team, pole}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// currentSeason — как в путях Ergast: сезон текущего года
+const currentSeason = "current"
+
+// firstSeason — первый чемпионат мира; от него считаем, пока список сезонов не загружен
+const firstSeason = 1950
+
+// loadSeasons заполняет выпадающие списки сезонов ответом /seasons (он кэшируется на сутки).
+// Пока список не пришёл, год можно ввести вручную
+func (v *SeasonView) loadSeasons() {
+	go func() {
+		seasons, err := v.app.client.Seasons(context.Background())
+		fyne.Do(func() {
+			if err != nil {
+				fmt.Println("Error loading seasons:", err)
+				return
+			}
+			v.seasons = make([]string, 0, len(seasons))
+			for _, season := range seasons {
+				v.seasons = append(v.seasons, season.Season)
+			}
+			options := []string{currentSeason}
+			for i := len(v.seasons) - 1; i >= 0; i-- { // новые сезоны сверху
+				options = append(options, v.seasons[i])
+			}
+			v.seasonEntryForInputScreen.SetOptions(options)
+			v.seasonEntryForDataView.SetOptions(options)
+			v.updateSeasonButtons()
+		})
+	}()
+}
+
+// resolveSeason проверяет введённый сезон и превращает "current" в год.
+// errMsg не пустой, если такого сезона нет
+func (v *SeasonView) resolveSeason(input string) (year, errMsg string) {
+	input = strings.TrimSpace(input)
+	if strings.EqualFold(input, currentSeason) {
+		return v.currentYear(), ""
+	}
+	yearInt, err := strconv.Atoi(input)
+	if len(v.seasons) == 0 { // список не загрузился — пропускаем и следующий год, его календарь может быть уже опубликован
+		lastYear := time.Now().Year() + 1
+		if err != nil || yearInt < firstSeason || yearInt > lastYear {
+			return "", fmt.Sprintf("Invalid season year. Must be a number between %d and %d or %q.", firstSeason, lastYear, currentSeason)
+		}
+		return input, ""
+	}
+	if err != nil || !slices.Contains(v.seasons, input) {
+		return "", fmt.Sprintf("Season %q is not available. Choose a season between %s and %s or %q.",
+			input, v.seasons[0], v.seasons[len(v.seasons)-1], currentSeason)
+	}
+	return input, ""
+}
+
+// currentYear — текущий год, а если его календаря ещё нет — последний известный сезон до него
+func (v *SeasonView) currentYear() string {
+	now := time.Now().Year()
+	for i := len(v.seasons) - 1; i >= 0; i-- {
+		if year, err := strconv.Atoi(v.seasons[i]); err == nil && year <= now {
+			return v.seasons[i]
+		}
+	}
+	return strconv.Itoa(now)
+}
+
+// adjacentSeason — сезон перед (delta < 0) или после (delta > 0) показанного; "" если его нет
+func (v *SeasonView) adjacentSeason(delta int) string {
+	if v.loadedSeason == "" {
+		return ""
+	}
+	if len(v.seasons) == 0 {
+		year, err := strconv.Atoi(v.loadedSeason)
+		year += delta
+		if err != nil || year < firstSeason || year > time.Now().Year()+1 {
+			return ""
+		}
+		return strconv.Itoa(year)
+	}
+	i := slices.Index(v.seasons, v.loadedSeason)
+	if i < 0 || i+delta < 0 || i+delta >= len(v.seasons) {
+		return ""
+	}
+	return v.seasons[i+delta]
+}
+
+// stepSeason загружает соседний сезон
+func (v *SeasonView) stepSeason(delta int) {
+	year := v.adjacentSeason(delta)
+	if year == "" {
+		return
+	}
+	v.seasonEntryForDataView.SetText(year)
+	v.loadDataForYear(year, v.statusTextForDataView, false)
+}
+
+// updateSeasonButtons выключает кнопки перехода, когда дальше сезонов нет
+func (v *SeasonView) updateSeasonButtons() {
+	for button, delta := range map[*widget.Button]int{v.prevSeasonButton: -1, v.nextSeasonButton: 1} {
+		if v.adjacentSeason(delta) == "" {
+			button.Disable()
+		} else {
+			button.Enable()
+		}
+	}
+}
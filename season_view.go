@@ -0,0 +1,823 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SeasonView — одно окно каталога: экран ввода года и экран с вкладками сезона.
+// Окон может быть несколько, у каждого свой сезон и свои загрузки
+type SeasonView struct {
+	app *App
+
+	window fyne.Window        // окно, в котором показан сезон
+	tabs   *container.AppTabs // вкладки "Races Calendar", "Race Results", "Qualifying", "Drivers", "Constructors" и зачёты
+
+	racesTabItem *container.TabItem // вкладка, где отображается список гонок и их детали
+
+	resultsSession *widget.RadioGroup // какую классификацию показывать на вкладке результатов: Grand Prix или Sprint
+
+	laps            *lapsPanel
+	lapsTabItem     *container.TabItem
+	pitStops        *pitStopsPanel
+	pitStopsTabItem *container.TabItem
+
+	circuitsMode       *widget.RadioGroup // трассы сезона или все трассы
+	circuitsTable      *widget.Table
+	circuits           []ergast.Circuit // строки circuitsTable
+	numColsCircuits    int
+	cancelCircuitsLoad context.CancelFunc
+
+	tabLoads map[*container.TabItem]func() // тяжёлые загрузки выбранной гонки, отложенные до открытия вкладки
+
+	resultsTable              *widget.Table
+	qualifyingTable           *widget.Table
+	driversTable              *widget.Table
+	constructorsTable         *widget.Table
+	driverStandingsTable      *widget.Table
+	constructorStandingsTable *widget.Table
+
+	resultsSort      *tableSort // выбранная сортировка таблиц результатов, пилотов и команд
+	driversSort      *tableSort
+	constructorsSort *tableSort
+
+	numColsResults              int
+	numColsQualifying           int
+	numColsDrivers              int
+	numColsConstructors         int
+	numColsDriverStandings      int
+	numColsConstructorStandings int
+
+	standingsRound      binding.Float  // номер этапа на ползунках зачёта, с 1
+	standingsRoundText  binding.String // подпись "After round N: ..."
+	standingsSliders    []*widget.Slider
+	cancelStandingsLoad context.CancelFunc
+
+	seasons []string // сезоны, о которых знает источник, по возрастанию; пусто, пока список не загружен
+
+	seasonEntryForInputScreen *widget.SelectEntry
+	statusTextForInputScreen  binding.String // текст состояния (ввод/ошибка/загрузка)
+	statusLabelForInputScreen *widget.Label
+
+	seasonEntryForDataView *widget.SelectEntry
+	statusTextForDataView  binding.String
+	statusLabelForDataView *widget.Label
+	prevSeasonButton       *widget.Button
+	nextSeasonButton       *widget.Button
+
+	nextUp *nextUpPanel // обратный отсчёт до ближайшей сессии текущего сезона
+
+	sessionTimes    *widget.RadioGroup // основное время сессий в деталях гонки
+	showRaceDetails func()             // перерисовывает детали выбранной гонки, например после смены основного времени
+
+	pendingRound string // этап, который выбрать после загрузки сезона (открытие из трея)
+
+	loadedSeason   string          // сезон, который сейчас показан на основном экране
+	seasonCtx      context.Context // контекст загрузки показанного сезона, отменяется при смене сезона
+	completedRaces []ergast.Race   // уже проведённые этапы показанного сезона
+
+	cancelSeasonLoad context.CancelFunc // отменяет текущую загрузку сезона
+	cancelRoundLoad  context.CancelFunc // отменяет загрузку результатов и квалификации выбранной гонки
+
+	inputScreen    fyne.CanvasObject // первый экран
+	dataViewScreen fyne.CanvasObject // основной экран
+}
+
+func newSeasonView(a *App, window fyne.Window) *SeasonView {
+	v := &SeasonView{app: a, window: window}
+	v.window.Resize(fyne.NewSize(1200, 700))
+
+	// Инициализация элементов для экрана ввода
+	v.seasonEntryForInputScreen = widget.NewSelectEntry(nil) // варианты придут из loadSeasons
+	v.seasonEntryForInputScreen.SetPlaceHolder("Pick or enter a season (e.g., 2023 or current)")
+
+	v.statusTextForInputScreen = binding.NewString() // автоматически обновляет текст при изменении переменной
+	v.statusLabelForInputScreen = widget.NewLabelWithData(v.statusTextForInputScreen)
+	v.statusLabelForInputScreen.Wrapping = fyne.TextWrapWord
+
+	loadButtonForInputScreen := widget.NewButtonWithIcon("Load Data", theme.SearchIcon(), func() {
+		v.loadDataForYear(v.seasonEntryForInputScreen.Text, v.statusTextForInputScreen, true)
+	})
+	v.seasonEntryForInputScreen.OnSubmitted = func(_ string) { loadButtonForInputScreen.OnTapped() }
+
+	// Создаем контейнер с фиксированной шириной поля ввода
+	wrappedEntry := container.NewGridWrap(fyne.NewSize(300, v.seasonEntryForInputScreen.MinSize().Height), v.seasonEntryForInputScreen)
+	inputContainer := container.NewVBox( // вертикально выравниваем
+		widget.NewLabel("Choose Formula 1 Season"),
+		wrappedEntry,
+		loadButtonForInputScreen,
+		v.statusLabelForInputScreen,
+	)
+	v.inputScreen = container.NewCenter(inputContainer) // централизуем
+
+	// Инициализация элементов для основного экрана
+	v.seasonEntryForDataView = widget.NewSelectEntry(nil)
+	v.seasonEntryForDataView.SetPlaceHolder("Pick or enter another season...")
+
+	v.statusTextForDataView = binding.NewString()
+	v.statusLabelForDataView = widget.NewLabelWithData(v.statusTextForDataView)
+	v.statusLabelForDataView.Wrapping = fyne.TextWrapWord // Если строка не помещается по ширине — перености её по словам на новую строку
+
+	loadButtonForDataView := widget.NewButtonWithIcon("Load New Season", theme.SearchIcon(), func() {
+		v.loadDataForYear(v.seasonEntryForDataView.Text, v.statusTextForDataView, false)
+	})
+	v.seasonEntryForDataView.OnSubmitted = func(_ string) { loadButtonForDataView.OnTapped() }
+
+	v.prevSeasonButton = widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() { v.stepSeason(-1) })
+	v.nextSeasonButton = widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() { v.stepSeason(1) })
+	v.prevSeasonButton.Disable() // включаются, когда загружен сезон
+	v.nextSeasonButton.Disable()
+
+	refreshButtonForDataView := widget.NewButtonWithIcon("Force Refresh", theme.ViewRefreshIcon(), func() {
+		if v.loadedSeason == "" {
+			return
+		}
+		if refresher, ok := v.app.client.(ergast.Refresher); ok { // выкидываем кэш сезона, чтобы данные пришли из сети
+			if err := refresher.Refresh(v.loadedSeason); err != nil {
+				fmt.Println("Error clearing cache:", err)
+			}
+		}
+		v.loadDataForYear(v.loadedSeason, v.statusTextForDataView, false)
+	})
+
+	var syncButtonForDataView *widget.Button
+	syncButtonForDataView = widget.NewButtonWithIcon("Sync Offline Data", theme.DownloadIcon(), func() {
+		syncButtonForDataView.Disable()
+		v.app.Sync(v.statusTextForDataView, syncButtonForDataView.Enable)
+	})
+	offlineCheckForDataView := widget.NewCheckWithData("Offline", v.app.offline)
+
+	newWindowButtonForDataView := widget.NewButtonWithIcon("Open in New Window", theme.ContentAddIcon(), func() {
+		v.app.NewSeasonWindow()
+	})
+
+	searchBarForDataView := container.NewBorder(nil, nil, container.NewHBox(v.prevSeasonButton, v.nextSeasonButton),
+		container.NewHBox(loadButtonForDataView, refreshButtonForDataView, syncButtonForDataView, offlineCheckForDataView, newWindowButtonForDataView),
+		v.seasonEntryForDataView)
+	v.nextUp = newNextUpPanel(v.app)
+	topPanelForDataView := container.NewVBox(searchBarForDataView, v.nextUp.content(), v.statusLabelForDataView)
+
+	// Инициализация таблиц
+	v.resultsTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },                       // строки, столбцы
+		func() fyne.CanvasObject { return widget.NewLabel("") }, // что писать
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},  // вызывается каждый раз, когда нужно обновить содержимое конкретной ячейки
+	)
+	v.qualifyingTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	v.driversTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	v.constructorsTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+
+	v.driverStandingsTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	v.constructorStandingsTable = widget.NewTable(
+		func() (int, int) { return 0, 0 },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {},
+	)
+	v.resultsSort = newTableSort()
+	v.driversSort = newTableSort()
+	v.constructorsSort = newTableSort()
+	v.standingsRound = binding.NewFloat()
+	v.standingsRoundText = binding.NewString()
+
+	// Создание вкладок
+	v.tabs = container.NewAppTabs()
+	initialRacesContent := container.NewCenter(widget.NewLabel("Data will appear here after loading a season."))
+	v.racesTabItem = container.NewTabItem("Races Calendar", initialRacesContent)
+	v.tabs.Append(v.racesTabItem)
+	v.resultsSession = widget.NewRadioGroup([]string{grandPrixSession, sprintSession}, nil)
+	v.resultsSession.Horizontal = true
+	v.resultsSession.Required = true
+	v.resultsSession.SetSelected(grandPrixSession)
+	v.resultsSession.Disable() // включается, когда выбран этап со спринтом
+	resultsContent := container.NewBorder(v.resultsSession, nil, nil, nil, container.NewVScroll(v.resultsTable))
+	v.tabs.Append(container.NewTabItem("Race Results", resultsContent))
+	v.tabs.Append(container.NewTabItem("Qualifying", container.NewVScroll(v.qualifyingTable)))
+	v.laps = newLapsPanel(v)
+	v.lapsTabItem = container.NewTabItem("Laps", v.laps.content())
+	v.tabs.Append(v.lapsTabItem)
+	v.pitStops = newPitStopsPanel(v)
+	v.pitStopsTabItem = container.NewTabItem("Pit Stops", v.pitStops.content())
+	v.tabs.Append(v.pitStopsTabItem)
+	v.tabs.Append(container.NewTabItem("Drivers", container.NewVScroll(v.driversTable)))
+	v.tabs.Append(container.NewTabItem("Constructors", container.NewVScroll(v.constructorsTable)))
+	v.tabs.Append(container.NewTabItem("Circuits", v.circuitsPanel()))
+	v.tabs.Append(container.NewTabItem("Driver Standings", v.standingsPanel(v.driverStandingsTable)))
+	v.tabs.Append(container.NewTabItem("Constructor Standings", v.standingsPanel(v.constructorStandingsTable)))
+
+	v.tabLoads = make(map[*container.TabItem]func())
+	v.tabs.OnSelected = func(tab *container.TabItem) {
+		if load, ok := v.tabLoads[tab]; ok {
+			delete(v.tabLoads, tab)
+			load()
+		}
+	}
+	v.dataViewScreen = container.NewBorder(topPanelForDataView, nil, nil, nil, v.tabs)
+
+	v.sessionTimes = sessionTimesChoice(v.app.sessionTimes)
+	sessionTimesListener := binding.NewDataListener(func() { // выбор могли поменять в другом окне
+		v.sessionTimes.SetSelected(v.app.primaryTime())
+		if v.showRaceDetails != nil {
+			v.showRaceDetails()
+		}
+	})
+	v.app.sessionTimes.AddListener(sessionTimesListener)
+	v.app.clockHandlers[v] = v.nextUp.refresh
+	keepInTrayListener := v.setupMenu()
+
+	v.window.SetContent(v.inputScreen)
+	v.loadSeasons()
+	v.window.SetOnClosed(func() { // закрыли окно — его загрузки больше не нужны
+		if v.cancelSeasonLoad != nil {
+			v.cancelSeasonLoad()
+		}
+		v.app.sessionTimes.RemoveListener(sessionTimesListener)
+		v.app.keepInTray.RemoveListener(keepInTrayListener)
+		delete(v.app.clockHandlers, v)
+	})
+	return v
+}
+
+func (v *SeasonView) resizeTableColumnsEqually(table *widget.Table, numCols int) {
+	if table == nil || numCols == 0 || v.tabs == nil || !v.tabs.Visible() || v.window.Content() != v.dataViewScreen {
+		return
+	}
+	availableWidth := v.tabs.Size().Width
+	if availableWidth <= 0 {
+		// fmt.Println("resizeTableColumnsEqually: availableWidth for tabs is 0 or less, cannot resize.")
+		return
+	}
+	setEqualColumnWidths(table, availableWidth, numCols)
+}
+
+// setEqualColumnWidths делит ширину availableWidth между колонками поровну
+func setEqualColumnWidths(table *widget.Table, availableWidth float32, numCols int) {
+	colWidth := availableWidth / float32(numCols)
+	minPracticalWidth := float32(50)
+
+	if colWidth < minPracticalWidth && float32(numCols)*minPracticalWidth > availableWidth {
+		colWidth = minPracticalWidth
+	} else if colWidth < 10 {
+		colWidth = 10
+	}
+
+	for i := 0; i < numCols; i++ {
+		table.SetColumnWidth(i, colWidth)
+	}
+}
+
+func (v *SeasonView) loadDataForYear(year string, statusUpdater binding.String, isFirstLoad bool) {
+	if v.cancelSeasonLoad != nil { // новый запрос отменяет предыдущую загрузку вместе с результатами гонок
+		v.cancelSeasonLoad()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancelSeasonLoad = cancel
+
+	statusUpdater.Set("Loading season data...")
+	if !isFirstLoad { // если не на стартовом экране - очищаем старые данные
+		v.resetUIDataForDataView()
+	}
+
+	if year == "" {
+		statusUpdater.Set("Please enter a season year.")
+		if !isFirstLoad { // Обновляем вкладку с гонками если это не первый экран
+			v.racesTabItem.Content = container.NewCenter(widget.NewLabel("Please enter a year in the search bar above."))
+			v.racesTabItem.Content.Refresh()
+		}
+		return
+	}
+
+	year, errMsg := v.resolveSeason(year)
+	if errMsg != "" {
+		statusUpdater.Set(errMsg)
+		if !isFirstLoad {
+			v.racesTabItem.Content = container.NewCenter(widget.NewLabel(errMsg))
+			v.racesTabItem.Content.Refresh()
+		}
+		return
+	}
+
+	go func() { // сеть не трогаем из UI-потока, иначе окно зависает на время запроса
+		sourceCtx, source := ergast.WithSourceRecorder(ctx) // данные могут быть из локальной копии — помечаем это в строке состояния
+		races, err := v.app.client.Season(sourceCtx, year)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return // пока грузили, пользователь запросил другой сезон
+			}
+			v.showSeason(ctx, year, races, err, source, statusUpdater, isFirstLoad)
+		})
+	}()
+}
+
+// showSeason выводит загруженный сезон. Вызывается в UI-потоке
+func (v *SeasonView) showSeason(ctx context.Context, year string, races []ergast.Race, err error, source *ergast.SourceRecorder, statusUpdater binding.String, isFirstLoad bool) {
+	placeholderErrorMsg := "Error loading data. Please check the year or your connection."
+	if err != nil {
+		errMsg := "Error " + err.Error()
+		placeholderMsg := placeholderErrorMsg
+		var statusErr *ergast.StatusError
+		if errors.As(err, &statusErr) { // сервер ответил, но не 200
+			errMsg = fmt.Sprintf("Server error: %d. No data or try a different year.", statusErr.Code)
+			placeholderMsg = errMsg
+		}
+		statusUpdater.Set(errMsg)
+		if !isFirstLoad {
+			v.racesTabItem.Content = container.NewCenter(widget.NewLabel(placeholderMsg))
+			v.racesTabItem.Content.Refresh()
+		}
+		return
+	}
+
+	if len(races) == 0 { // если гонок нет, то сообщаем об этом
+		errMsg := fmt.Sprintf("No races found for season %s.", year)
+		statusUpdater.Set(errMsg)
+		if !isFirstLoad {
+			v.racesTabItem.Content = container.NewCenter(widget.NewLabel(errMsg))
+			v.racesTabItem.Content.Refresh()
+		}
+		return
+	}
+
+	seasonWikiLink := widget.NewHyperlink("Season Info", nil)
+	wikiURLStr := fmt.Sprintf("https://en.wikipedia.org/wiki/%s_Formula_One_World_Championship", year)
+	parsedWikiURL := parseURL(wikiURLStr)
+	if parsedWikiURL != nil {
+		seasonWikiLink.SetURL(parsedWikiURL)
+	} else {
+		seasonWikiLink.SetText("Season Info (URL error)")
+	}
+
+	// Создаем список гонок, поле для информации о выбранной гонке и ссылку на Википедию
+	raceList := widget.NewList(
+		func() int { return len(races) },
+		func() fyne.CanvasObject { return widget.NewLabel("Race Template") },
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(fmt.Sprintf("Round %s: %s", races[id].Round, races[id].RaceName))
+		},
+	)
+	raceInfoText := widget.NewLabel("Select a race to see details.")
+	raceInfoText.Wrapping = fyne.TextWrapWord
+	raceWikiLink := widget.NewHyperlink("", nil)
+	var raceToExport *ergast.Race // выбранная гонка
+	exportRaceButton := widget.NewButtonWithIcon("Export Race to Calendar (.ics)", theme.CalendarIcon(), func() {
+		if raceToExport != nil {
+			v.exportCalendar(year, fmt.Sprintf("f1-%s-round-%s.ics", year, raceToExport.Round), []ergast.Race{*raceToExport})
+		}
+	})
+	exportRaceButton.Disable() // пока не выбрана гонка
+	exportSeasonButton := widget.NewButtonWithIcon("Export Season to Calendar (.ics)", theme.CalendarIcon(), func() {
+		v.exportCalendar(year, fmt.Sprintf("f1-%s.ics", year), races)
+	})
+	raceDetailsContainer := container.NewVScroll(container.NewVBox(raceInfoText, raceWikiLink, container.NewHBox(exportRaceButton)))
+	split := container.NewHSplit(container.NewVScroll(raceList), raceDetailsContainer)
+	split.SetOffset(0.3)
+	sessionTimesBar := container.NewBorder(nil, nil, nil, container.NewHBox(widget.NewLabel("Session times:"), v.sessionTimes),
+		container.NewHBox(seasonWikiLink, exportSeasonButton))
+	topContentForRacesTab := container.NewVBox(sessionTimesBar, widget.NewSeparator())
+	v.racesTabItem.Content = container.NewBorder(topContentForRacesTab, nil, nil, nil, split)
+	v.racesTabItem.Content.Refresh()
+
+	raceList.OnSelected = func(id widget.ListItemID) { // показываем гонку на которую нажал пользователь
+		if id >= 0 && id < len(races) {
+			selectedRace := races[id]
+			raceToExport = &races[id]
+			exportRaceButton.Enable()
+			v.showRaceDetails = func() { showRaceDetails(selectedRace, raceInfoText, raceWikiLink, v.app.primaryTime()) }
+			v.showRaceDetails()
+			roundCtx := v.newRoundContext(ctx)
+			v.selectResultsSession(roundCtx, year, selectedRace)
+			v.loadQualifying(roundCtx, year, selectedRace.Round, v.qualifyingTable)
+			v.laps.reset("Loading laps...")
+			v.loadWhenVisible(v.lapsTabItem, func() { v.laps.load(roundCtx, year, selectedRace.Round) })
+			v.pitStops.reset("Loading pit stops...", false)
+			v.loadWhenVisible(v.pitStopsTabItem, func() { v.pitStops.load(roundCtx, year, selectedRace.Round) })
+		}
+	}
+	if len(races) > 0 {
+		next := max(upcomingRaceIndex(races, time.Now()), 0) // завершённый сезон открываем с первого этапа
+		if i := slices.IndexFunc(races, func(race ergast.Race) bool { return race.Round == v.pendingRound }); i >= 0 {
+			next = i
+		}
+		v.pendingRound = ""
+		raceList.Select(next)
+		raceList.ScrollTo(next)
+	}
+
+	v.loadedSeason = year
+	v.seasonCtx = ctx
+	v.completedRaces = completedRaces(races)
+	v.updateSeasonButtons()
+	if len(v.seasons) == 0 { // список сезонов не загрузился при открытии окна — пробуем ещё раз
+		v.loadSeasons()
+	}
+	v.window.SetTitle("F1 Race Catalog — " + year)
+
+	// Загружаем списки пилотов и конструкторов на отдельные вкладки
+	v.loadDrivers(ctx, year, v.driversTable)
+	v.loadConstructors(ctx, year, v.constructorsTable)
+	v.setupStandings()
+	v.loadCircuits()
+
+	// Если это первый запуск — переключаемся с input-экрана на экран с вкладками
+	if source.FromStore() {
+		statusUpdater.Set(fmt.Sprintf("Season %s loaded from %s.", year, source.Source()))
+		v.statusLabelForDataView.Importance = widget.WarningImportance
+	} else {
+		statusUpdater.Set(fmt.Sprintf("Season %s loaded successfully! Source: %s", year, sourceName(source.Source())))
+		v.statusLabelForDataView.Importance = widget.MediumImportance
+	}
+	v.statusLabelForDataView.Refresh()
+	v.seasonEntryForDataView.SetText(year) // вместо "current" показываем сам год
+	if isFirstLoad {
+		v.statusTextForInputScreen.Set("")
+		v.window.SetContent(v.dataViewScreen)
+	}
+
+	// Горутина тут нужна, чтобы не блокировался UI и выполнилось обновление интерфейса после задержки
+	go func() { // Через полсекунды обновляем ширину колонок, чтобы красиво отображались данные
+		time.Sleep(150 * time.Millisecond) // Даем Fyne время отрисовать и обновить размеры
+		// виджеты трогаем только из UI-потока
+		fyne.Do(func() {
+			if v.window.Content() == v.dataViewScreen && v.tabs != nil {
+				v.tabs.Refresh() // Обновляем сам контейнер вкладок, чтобы его Size() был актуален
+			}
+			v.resizeAllVisibleTables()
+		})
+	}() // сразу ее вызываем
+}
+
+func (v *SeasonView) resizeAllVisibleTables() {
+	if v.window.Content() == v.dataViewScreen {
+		if v.resultsTable != nil && v.numColsResults > 0 {
+			v.resizeTableColumnsEqually(v.resultsTable, v.numColsResults)
+		}
+		if v.qualifyingTable != nil && v.numColsQualifying > 0 {
+			v.resizeTableColumnsEqually(v.qualifyingTable, v.numColsQualifying)
+		}
+		if v.driversTable != nil && v.numColsDrivers > 0 {
+			v.resizeTableColumnsEqually(v.driversTable, v.numColsDrivers)
+		}
+		if v.constructorsTable != nil && v.numColsConstructors > 0 {
+			v.resizeTableColumnsEqually(v.constructorsTable, v.numColsConstructors)
+		}
+		if v.laps != nil && v.laps.numCols > 0 {
+			v.resizeTableColumnsEqually(v.laps.table, v.laps.numCols)
+		}
+		if v.circuitsTable != nil && v.numColsCircuits > 0 {
+			v.resizeTableColumnsEqually(v.circuitsTable, v.numColsCircuits)
+		}
+		if v.pitStops != nil && v.pitStops.numColsRace > 0 {
+			v.resizeTableColumnsEqually(v.pitStops.raceTable, v.pitStops.numColsRace)
+		}
+		if v.pitStops != nil && v.pitStops.numColsSeason > 0 {
+			v.resizeTableColumnsEqually(v.pitStops.seasonTable, v.pitStops.numColsSeason)
+		}
+		if v.driverStandingsTable != nil && v.numColsDriverStandings > 0 {
+			v.resizeTableColumnsEqually(v.driverStandingsTable, v.numColsDriverStandings)
+		}
+		if v.constructorStandingsTable != nil && v.numColsConstructorStandings > 0 {
+			v.resizeTableColumnsEqually(v.constructorStandingsTable, v.numColsConstructorStandings)
+		}
+	}
+}
+
+func (v *SeasonView) resetUIDataForDataView() {
+	v.showRaceDetails = nil
+	if v.racesTabItem != nil {
+		v.racesTabItem.Content = container.NewCenter(widget.NewLabel("Loading data or waiting for year input..."))
+		v.racesTabItem.Content.Refresh()
+	}
+	if v.resultsTable != nil {
+		resetTable(v.resultsTable)
+	}
+	if v.qualifyingTable != nil {
+		resetTable(v.qualifyingTable)
+	}
+	if v.driversTable != nil {
+		resetTable(v.driversTable)
+	}
+	if v.constructorsTable != nil {
+		resetTable(v.constructorsTable)
+	}
+	if v.laps != nil {
+		v.laps.reset("Select a race to see its laps.")
+	}
+	if v.circuitsTable != nil {
+		resetTable(v.circuitsTable)
+	}
+	v.numColsCircuits = 0
+	v.circuits = nil
+	if v.pitStops != nil {
+		v.pitStops.reset("Select a race to see its pit stops.", true)
+	}
+	clear(v.tabLoads)
+	if v.driverStandingsTable != nil {
+		resetTable(v.driverStandingsTable)
+	}
+	if v.constructorStandingsTable != nil {
+		resetTable(v.constructorStandingsTable)
+	}
+	v.numColsResults = 0
+	v.numColsQualifying = 0
+	v.numColsDrivers = 0
+	v.numColsConstructors = 0
+	v.numColsDriverStandings = 0
+	v.numColsConstructorStandings = 0
+	v.completedRaces = nil // пока грузится новый сезон, ползунки зачёта не работают
+	for _, slider := range v.standingsSliders {
+		slider.Disable()
+	}
+	v.standingsRoundText.Set("")
+}
+
+// completedRaces — этапы, гонки которых уже закончились (старт плюс примерная длительность гонки)
+func completedRaces(races []ergast.Race) []ergast.Race {
+	var completed []ergast.Race
+	now := time.Now()
+	for _, race := range races {
+		if start, ok := race.Start(); ok && start.Add(sessionDurations["Race"]).Before(now) {
+			completed = append(completed, race)
+		}
+	}
+	return completed
+}
+
+// loadWhenVisible запускает load сразу, если вкладка tab открыта, иначе — когда её откроют.
+// Новая отложенная загрузка заменяет прежнюю
+func (v *SeasonView) loadWhenVisible(tab *container.TabItem, load func()) {
+	if v.tabs.Selected() == tab {
+		delete(v.tabLoads, tab)
+		load()
+		return
+	}
+	v.tabLoads[tab] = load
+}
+
+// newRoundContext отменяет загрузки предыдущей выбранной гонки и создаёт контекст для новой
+func (v *SeasonView) newRoundContext(seasonCtx context.Context) context.Context {
+	if v.cancelRoundLoad != nil { // выбрали другую гонку — старые запросы больше не нужны
+		v.cancelRoundLoad()
+	}
+	ctx, cancel := context.WithCancel(seasonCtx)
+	v.cancelRoundLoad = cancel
+	return ctx
+}
+
+// Классификации, между которыми переключается вкладка результатов
+const (
+	grandPrixSession = "Grand Prix"
+	sprintSession    = "Sprint"
+)
+
+// selectResultsSession включает переключатель Grand Prix/Sprint только для спринт-этапов
+// и загружает выбранную классификацию гонки race
+func (v *SeasonView) selectResultsSession(ctx context.Context, year string, race ergast.Race) {
+	v.resultsSession.OnChanged = nil // SetSelected ниже не должен запускать загрузку второй раз
+	if race.Sprint == nil {
+		v.resultsSession.SetSelected(grandPrixSession)
+		v.resultsSession.Disable()
+	} else {
+		v.resultsSession.Enable() // выбор пользователя сохраняется между спринт-этапами
+	}
+	v.resultsSession.OnChanged = func(string) {
+		v.loadRaceResults(ctx, year, race.Round, v.resultsTable)
+	}
+	v.loadRaceResults(ctx, year, race.Round, v.resultsTable)
+}
+
+func (v *SeasonView) loadRaceResults(ctx context.Context, year, round string, table *widget.Table) {
+	resetTable(table)
+	v.numColsResults = 0
+	if year == "" || round == "" {
+		return
+	}
+	session := v.resultsSession.Selected
+	go func() {
+		var results []ergast.RaceResult
+		var err error
+		if session == sprintSession {
+			var sprint []ergast.SprintResult
+			sprint, err = v.app.client.SprintResults(ctx, year, round)
+			for _, result := range sprint { // у спринта те же колонки, что и у гонки
+				results = append(results, ergast.RaceResult(result))
+			}
+		} else {
+			results, err = v.app.client.Results(ctx, year, round)
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil || v.resultsSession.Selected != session {
+				return // пока грузили, выбрали другую гонку или другую классификацию
+			}
+			if err != nil {
+				fmt.Println("Error loading race results:", err)
+				return
+			}
+			v.showRaceResults(results, table)
+		})
+	}()
+}
+
+func (v *SeasonView) showRaceResults(results []ergast.RaceResult, table *widget.Table) {
+	if len(results) == 0 {
+		resetTable(table) // Если результатов нет — таблица остается пустой
+		return
+	}
+	timeCol := slices.Index(raceResultHeaders, "Time/Retired")
+	keys := make([][]string, len(results))
+	for i, result := range results {
+		keys[i] = raceResultRow(result)
+		if result.Time != nil && result.Time.Millis != "" { // "+5.123" у второго места меньше времени победителя — сравниваем полное время
+			keys[i][timeCol] = result.Time.Millis
+		}
+	}
+	unsorted := results
+	results = sortedBy(results, v.resultsSort.order(keys))
+
+	headers := raceResultHeaders
+	v.numColsResults = len(headers)
+	numCols := v.numColsResults
+	table.Length = func() (int, int) { return len(results) + 1, numCols }
+	table.UpdateCell = func(id widget.TableCellID, cell fyne.CanvasObject) {
+		label := cell.(*widget.Label)
+		label.Alignment = fyne.TextAlignLeading
+		label.Importance = widget.MediumImportance // ячейки переиспользуются — сбрасываем подсветку
+		if id.Row == 0 {
+			label.Alignment = fyne.TextAlignCenter
+			if id.Col < len(headers) {
+				label.SetText(v.resultsSort.header(headers, id.Col))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+			}
+			return
+		}
+		label.TextStyle = fyne.TextStyle{} //Если Row == 0, это строка заголовков - пишем bold-текст, если Row > 0, это данные гонки
+		resultIndex := id.Row - 1
+		if resultIndex >= len(results) {
+			return
+		}
+		if hasFastestLap(results[resultIndex]) { // подсвечиваем быстрейший круг гонки
+			label.Importance = widget.SuccessImportance
+			label.TextStyle = fyne.TextStyle{Bold: true}
+		}
+		if row := raceResultRow(results[resultIndex]); id.Col < len(row) {
+			label.SetText(row[id.Col])
+		}
+	}
+	table.Refresh() // перерисовываем таблицу на экране
+	v.app.linkProfiles(table,
+		rowDrivers(results, func(r ergast.RaceResult) ergast.Driver { return r.Driver }),
+		rowConstructors(results, raceResultHeaders, func(r ergast.RaceResult) ergast.Constructor { return r.Constructor }))
+	v.resultsSort.handleHeaderClicks(table, func() { v.showRaceResults(unsorted, table) })
+	v.resizeTableColumnsEqually(table, v.numColsResults)
+}
+
+func (v *SeasonView) loadDrivers(ctx context.Context, year string, table *widget.Table) {
+	resetTable(table)
+	v.numColsDrivers = 0
+	if year == "" {
+		return
+	}
+	go func() {
+		drivers, err := v.app.client.Drivers(ctx, year)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Println("Error loading drivers:", err)
+				return
+			}
+			v.showDrivers(drivers, table)
+		})
+	}()
+}
+
+func (v *SeasonView) showDrivers(drivers []ergast.Driver, table *widget.Table) {
+	if len(drivers) == 0 {
+		resetTable(table)
+		return
+	}
+	keys := make([][]string, len(drivers))
+	for i, driver := range drivers {
+		keys[i] = driverRow(driver)
+	}
+	unsorted := drivers
+	drivers = sortedBy(drivers, v.driversSort.order(keys))
+
+	headers := driverHeaders
+	v.numColsDrivers = len(headers)
+	numCols := v.numColsDrivers
+	table.Length = func() (int, int) { return len(drivers) + 1, numCols }
+	table.UpdateCell = func(id widget.TableCellID, cell fyne.CanvasObject) {
+		label := cell.(*widget.Label)
+		label.Alignment = fyne.TextAlignLeading
+		if id.Row == 0 {
+			label.Alignment = fyne.TextAlignCenter
+			if id.Col < len(headers) {
+				label.SetText(v.driversSort.header(headers, id.Col))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+			}
+			return
+		}
+		label.TextStyle = fyne.TextStyle{}
+		driverIndex := id.Row - 1
+		if driverIndex >= len(drivers) {
+			return
+		}
+		if row := driverRow(drivers[driverIndex]); id.Col < len(row) {
+			label.SetText(row[id.Col])
+		}
+	}
+	v.app.linkProfiles(table, rowDrivers(drivers, func(d ergast.Driver) ergast.Driver { return d }), nil)
+	v.driversSort.handleHeaderClicks(table, func() { v.showDrivers(unsorted, table) })
+	table.Refresh()
+	v.resizeTableColumnsEqually(table, v.numColsDrivers)
+}
+
+func (v *SeasonView) loadConstructors(ctx context.Context, year string, table *widget.Table) {
+	resetTable(table)
+	v.numColsConstructors = 0
+	if year == "" {
+		return
+	}
+	go func() {
+		constructors, err := v.app.client.Constructors(ctx, year)
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fmt.Println("Error loading constructors:", err)
+				return
+			}
+			v.showConstructors(constructors, table)
+		})
+	}()
+}
+
+func (v *SeasonView) showConstructors(constructors []ergast.Constructor, table *widget.Table) {
+	if len(constructors) == 0 {
+		resetTable(table)
+		return
+	}
+	keys := make([][]string, len(constructors))
+	for i, constructor := range constructors {
+		keys[i] = constructorRow(constructor)
+	}
+	unsorted := constructors
+	constructors = sortedBy(constructors, v.constructorsSort.order(keys))
+
+	headers := constructorHeaders
+	v.numColsConstructors = len(headers)
+	numCols := v.numColsConstructors
+	table.Length = func() (int, int) { return len(constructors) + 1, numCols }
+	table.UpdateCell = func(id widget.TableCellID, cell fyne.CanvasObject) {
+		label := cell.(*widget.Label)
+		label.Alignment = fyne.TextAlignLeading
+		if id.Row == 0 {
+			label.Alignment = fyne.TextAlignCenter
+			if id.Col < len(headers) {
+				label.SetText(v.constructorsSort.header(headers, id.Col))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+			}
+			return
+		}
+		label.TextStyle = fyne.TextStyle{}
+		constructorIndex := id.Row - 1
+		if constructorIndex >= len(constructors) {
+			return
+		}
+		if row := constructorRow(constructors[constructorIndex]); id.Col < len(row) {
+			label.SetText(row[id.Col])
+		}
+	}
+	v.app.linkProfiles(table, nil, rowConstructors(constructors, constructorHeaders, func(c ergast.Constructor) ergast.Constructor { return c }))
+	v.constructorsSort.handleHeaderClicks(table, func() { v.showConstructors(unsorted, table) })
+	table.Refresh()
+	v.resizeTableColumnsEqually(table, v.numColsConstructors)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Какое время сессий показывать первым; выбор хранится в настройках приложения
+const (
+	yourTime    = "Your Time"
+	circuitTime = "Circuit Time"
+)
+
+const sessionTimesPreference = "sessionTimes"
+
+// Форматы времени сессии: основное — с датой и годом, второе — покороче
+const (
+	primaryTimeLayout   = "Mon 2 Jan 2006 15:04 MST"
+	secondaryTimeLayout = "Mon 2 Jan 15:04 MST"
+	sessionDateLayout   = "Mon 2 Jan 2006"
+)
+
+// formatSessionTime показывает начало сессии во времени зрителя и во времени трассы;
+// primary выбирает, какое из них идёт первым
+func formatSessionTime(session ergast.NamedSession, circuitZone *time.Location, primary string) string {
+	if !session.HasTime { // в старых сезонах только дата — переводить её в другой пояс нельзя
+		return session.Start.Format(sessionDateLayout)
+	}
+	local := session.Start.Local()
+	atCircuit := session.Start.In(circuitZone)
+	if primary == circuitTime {
+		return fmt.Sprintf("%s (your time: %s)", atCircuit.Format(primaryTimeLayout), local.Format(secondaryTimeLayout))
+	}
+	return fmt.Sprintf("%s (circuit: %s)", local.Format(primaryTimeLayout), atCircuit.Format(secondaryTimeLayout))
+}
+
+// sessionTimesChoice — переключатель основного времени. Привязан к общей настройке,
+// поэтому во всех окнах выбор один и тот же
+func sessionTimesChoice(preference binding.String) *widget.RadioGroup {
+	choice := widget.NewRadioGroup([]string{yourTime, circuitTime}, func(selected string) { preference.Set(selected) })
+	choice.Horizontal = true
+	choice.Required = true
+	return choice
+}
+
+// primaryTime — выбранное основное время; по умолчанию время зрителя
+func (a *App) primaryTime() string {
+	if primary, _ := a.sessionTimes.Get(); primary == circuitTime {
+		return circuitTime
+	}
+	return yourTime
+}
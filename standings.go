@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// standingsPanel — вкладка зачёта с ползунком этапа. Ползунки обеих вкладок
+// привязаны к одному значению, поэтому этап на них всегда совпадает
+func (v *SeasonView) standingsPanel(table *widget.Table) fyne.CanvasObject {
+	slider := widget.NewSliderWithData(1, 2, v.standingsRound)
+	slider.Step = 1
+	slider.OnChangeEnded = func(float64) { v.loadStandings() } // грузим, только когда ползунок отпустили
+	slider.Disable()
+	v.standingsSliders = append(v.standingsSliders, slider)
+
+	top := container.NewBorder(nil, nil, widget.NewLabelWithData(v.standingsRoundText), nil, slider)
+	return container.NewBorder(top, nil, nil, nil, container.NewVScroll(table))
+}
+
+// setupStandings настраивает ползунки на проведённые этапы сезона и показывает
+// положение после последнего из них. Вызывается в UI-потоке
+func (v *SeasonView) setupStandings() {
+	rounds := len(v.completedRaces)
+	for _, slider := range v.standingsSliders {
+		slider.Max = float64(max(rounds, 2)) // у ползунка Min и Max не должны совпадать
+		if rounds > 1 {
+			slider.Enable()
+		} else {
+			slider.Disable()
+		}
+		slider.Refresh()
+	}
+	v.standingsRound.Set(float64(max(rounds, 1)))
+	v.loadStandings()
+}
+
+// loadStandings загружает оба зачёта после этапа, выбранного ползунком. Пока результаты этапа
+// не опубликованы (или их нет в локальной копии), показывает последнее опубликованное положение
+func (v *SeasonView) loadStandings() {
+	if v.cancelStandingsLoad != nil {
+		v.cancelStandingsLoad()
+	}
+	resetTable(v.driverStandingsTable)
+	resetTable(v.constructorStandingsTable)
+	v.numColsDriverStandings = 0
+	v.numColsConstructorStandings = 0
+	if v.seasonCtx == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(v.seasonCtx)
+	v.cancelStandingsLoad = cancel
+
+	round, label := "", "Latest standings"
+	if len(v.completedRaces) > 0 {
+		value, _ := v.standingsRound.Get()
+		race := v.completedRaces[min(max(int(value), 1), len(v.completedRaces))-1]
+		round, label = race.Round, fmt.Sprintf("After round %s: %s", race.Round, race.RaceName)
+	}
+	v.standingsRoundText.Set(label)
+
+	year := v.loadedSeason
+	go func() {
+		drivers, driversErr := v.app.client.DriverStandings(ctx, year, round)
+		constructors, constructorsErr := v.app.client.ConstructorStandings(ctx, year, round)
+		latest := false
+		if round != "" && ctx.Err() == nil && (driversErr != nil || len(drivers) == 0) {
+			latest = true // гонка только что закончилась — зачёта после неё ещё нет
+			drivers, driversErr = v.app.client.DriverStandings(ctx, year, "")
+			constructors, constructorsErr = v.app.client.ConstructorStandings(ctx, year, "")
+		}
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			switch {
+			case driversErr == nil && len(drivers) == 0:
+				v.standingsRoundText.Set("No rounds completed yet")
+			case latest:
+				v.standingsRoundText.Set(label + " (not published yet, showing latest standings)")
+			}
+			if driversErr != nil {
+				fmt.Println("Error loading driver standings:", driversErr)
+			} else {
+				v.showDriverStandings(drivers, v.driverStandingsTable)
+			}
+			if constructorsErr != nil {
+				fmt.Println("Error loading constructor standings:", constructorsErr)
+			} else {
+				v.showConstructorStandings(constructors, v.constructorStandingsTable)
+			}
+		})
+	}()
+}
+
+func (v *SeasonView) showDriverStandings(standings []ergast.DriverStanding, table *widget.Table) {
+	rows := make([][]string, 0, len(standings))
+	for _, standing := range standings {
+		rows = append(rows, driverStandingRow(standing))
+	}
+	v.numColsDriverStandings = showRows(table, driverStandingHeaders, rows)
+	v.app.linkProfiles(table,
+		rowDrivers(standings, func(s ergast.DriverStanding) ergast.Driver { return s.Driver }),
+		rowConstructors(standings, driverStandingHeaders, func(s ergast.DriverStanding) ergast.Constructor {
+			if len(s.Constructors) == 0 {
+				return ergast.Constructor{}
+			}
+			return s.Constructors[len(s.Constructors)-1] // последняя команда пилота в сезоне
+		}))
+	v.resizeTableColumnsEqually(table, v.numColsDriverStandings)
+}
+
+func (v *SeasonView) showConstructorStandings(standings []ergast.ConstructorStanding, table *widget.Table) {
+	rows := make([][]string, 0, len(standings))
+	for _, standing := range standings {
+		rows = append(rows, constructorStandingRow(standing))
+	}
+	v.numColsConstructorStandings = showRows(table, constructorStandingHeaders, rows)
+	v.app.linkProfiles(table, nil, rowConstructors(standings, constructorStandingHeaders, func(s ergast.ConstructorStanding) ergast.Constructor { return s.Constructor }))
+	v.resizeTableColumnsEqually(table, v.numColsConstructorStandings)
+}
+
+// showRows заполняет таблицу заголовком и строками и возвращает число колонок.
+// Пустая таблица (до 1958 года Кубка конструкторов не было) остаётся без колонок
+func showRows(table *widget.Table, headers []string, rows [][]string) int {
+	if len(rows) == 0 {
+		resetTable(table)
+		return 0
+	}
+	numCols := len(headers)
+	table.Length = func() (int, int) { return len(rows) + 1, numCols }
+	table.UpdateCell = func(id widget.TableCellID, cell fyne.CanvasObject) {
+		label := cell.(*widget.Label)
+		label.Alignment = fyne.TextAlignLeading
+		if id.Row == 0 {
+			label.Alignment = fyne.TextAlignCenter
+			if id.Col < len(headers) {
+				label.SetText(headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+			}
+			return
+		}
+		label.TextStyle = fyne.TextStyle{}
+		rowIndex := id.Row - 1
+		if rowIndex >= len(rows) || id.Col >= len(rows[rowIndex]) {
+			return
+		}
+		label.SetText(rows[rowIndex][id.Col])
+	}
+	table.Refresh()
+	return numCols
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"image/color"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// stintRow — полоса одного пилота: отрезки кругов между пит-стопами
+type stintRow struct {
+	label  string
+	color  color.NRGBA
+	stints [][2]int // [первый круг, последний круг]
+}
+
+// stintChart — диаграмма Ганта по отрезкам (стинтам): строка на пилота, ось X — круги
+type stintChart struct {
+	widget.BaseWidget
+
+	rows []stintRow
+	laps int
+}
+
+func newStintChart() *stintChart {
+	chart := &stintChart{}
+	chart.ExtendBaseWidget(chart)
+	return chart
+}
+
+// SetRows заменяет данные диаграммы. Вызывается в UI-потоке
+func (c *stintChart) SetRows(rows []stintRow) {
+	c.rows = rows
+	c.laps = 0
+	for _, row := range rows {
+		if len(row.stints) > 0 {
+			c.laps = max(c.laps, row.stints[len(row.stints)-1][1])
+		}
+	}
+	c.Refresh()
+}
+
+func (c *stintChart) CreateRenderer() fyne.WidgetRenderer {
+	r := &stintChartRenderer{chart: c}
+	r.rebuild()
+	return r
+}
+
+// Размеры диаграммы: высота строки и поля под коды пилотов и номера кругов
+const (
+	stintRowHeight    = 20
+	stintMarginLeft   = 60
+	stintMarginRight  = 10
+	stintMarginBottom = 20
+)
+
+// stintBar — прямоугольник стинта, координаты в строках и кругах
+type stintBar struct {
+	rect           *canvas.Rectangle
+	row            int
+	fromLap, toLap int
+}
+
+type stintChartRenderer struct {
+	chart     *stintChart
+	bars      []stintBar
+	rowLabels []*canvas.Text
+	lapLabels []*canvas.Text
+	lapTicks  []int
+	objects   []fyne.CanvasObject
+}
+
+func (r *stintChartRenderer) rebuild() {
+	r.bars, r.rowLabels, r.lapLabels, r.lapTicks, r.objects = nil, nil, nil, nil, nil
+	c := r.chart
+	if c.laps == 0 {
+		return
+	}
+	textColor := theme.Color(theme.ColorNameForeground)
+	for i, row := range c.rows {
+		text := canvas.NewText(row.label, textColor)
+		text.TextSize = theme.CaptionTextSize()
+		r.rowLabels = append(r.rowLabels, text)
+		for n, stint := range row.stints {
+			fill := row.color
+			if n%2 == 1 { // соседние стинты различаем яркостью
+				fill.A = 0x80
+			}
+			r.bars = append(r.bars, stintBar{rect: canvas.NewRectangle(fill), row: i, fromLap: stint[0], toLap: stint[1]})
+		}
+	}
+	for lap := 0; lap <= c.laps; lap += lapTickStep(c.laps) {
+		text := canvas.NewText(strconv.Itoa(lap), textColor)
+		text.TextSize = theme.CaptionTextSize()
+		r.lapLabels = append(r.lapLabels, text)
+		r.lapTicks = append(r.lapTicks, lap)
+	}
+
+	for _, bar := range r.bars {
+		r.objects = append(r.objects, bar.rect)
+	}
+	for _, text := range r.rowLabels {
+		r.objects = append(r.objects, text)
+	}
+	for _, text := range r.lapLabels {
+		r.objects = append(r.objects, text)
+	}
+}
+
+func (r *stintChartRenderer) lapX(size fyne.Size, lap int) float32 {
+	width := size.Width - stintMarginLeft - stintMarginRight
+	return stintMarginLeft + width*float32(lap)/float32(max(r.chart.laps, 1))
+}
+
+func (r *stintChartRenderer) Layout(size fyne.Size) {
+	for _, bar := range r.bars {
+		x1, x2 := r.lapX(size, bar.fromLap), r.lapX(size, bar.toLap)
+		bar.rect.Move(fyne.NewPos(x1+1, float32(bar.row*stintRowHeight)+2)) // зазор в 1 пиксель отделяет стинты
+		bar.rect.Resize(fyne.NewSize(max(x2-x1-2, 1), stintRowHeight-4))
+	}
+	for i, text := range r.rowLabels {
+		textSize := text.MinSize()
+		text.Resize(textSize)
+		text.Move(fyne.NewPos(2, float32(i*stintRowHeight)+(stintRowHeight-textSize.Height)/2))
+	}
+	bottom := float32(len(r.chart.rows) * stintRowHeight)
+	for i, text := range r.lapLabels {
+		textSize := text.MinSize()
+		text.Resize(textSize)
+		text.Move(fyne.NewPos(r.lapX(size, r.lapTicks[i])-textSize.Width/2, bottom+2))
+	}
+}
+
+func (r *stintChartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(400, float32(len(r.chart.rows)*stintRowHeight+stintMarginBottom))
+}
+
+func (r *stintChartRenderer) Refresh() {
+	r.rebuild()
+	r.Layout(r.chart.Size())
+	canvas.Refresh(r.chart)
+}
+
+func (r *stintChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *stintChartRenderer) Destroy() {}
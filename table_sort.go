@@ -0,0 +1,137 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// tableSort — сортировка таблицы по клику на заголовок. Хранится в SeasonView,
+// поэтому выбранная колонка сохраняется, когда таблицу заполняют данными другого этапа или сезона
+type tableSort struct {
+	col        int // -1 — порядок, в котором данные пришли из API
+	descending bool
+}
+
+func newTableSort() *tableSort {
+	return &tableSort{col: -1}
+}
+
+// toggle — клик по заголовку: повторный клик меняет направление, клик по другой колонке сортирует по возрастанию
+func (s *tableSort) toggle(col int) {
+	if s.col == col {
+		s.descending = !s.descending
+		return
+	}
+	s.col, s.descending = col, false
+}
+
+// header — название колонки со стрелкой, если таблица отсортирована по ней
+func (s *tableSort) header(headers []string, col int) string {
+	if col >= len(headers) {
+		return ""
+	}
+	if col != s.col {
+		return headers[col]
+	}
+	if s.descending {
+		return headers[col] + " ↓"
+	}
+	return headers[col] + " ↑"
+}
+
+// order — индексы строк keys в порядке сортировки. Пустые ячейки всегда в конце,
+// при равенстве сохраняется исходный порядок
+func (s *tableSort) order(keys [][]string) []int {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	if s.col < 0 {
+		return order
+	}
+	cell := func(row int) string {
+		if s.col < len(keys[row]) {
+			return strings.TrimSpace(keys[row][s.col])
+		}
+		return ""
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := cell(order[i]), cell(order[j])
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		if s.descending {
+			return compareCells(b, a) < 0
+		}
+		return compareCells(a, b) < 0
+	})
+	return order
+}
+
+// handleHeaderClicks делает заголовки таблицы кликабельными; resort перерисовывает таблицу
+// в новом порядке. Клики по остальным ячейкам уходят в прежний OnSelected (профили)
+func (s *tableSort) handleHeaderClicks(table *widget.Table, resort func()) {
+	onSelected := table.OnSelected
+	table.OnSelected = func(id widget.TableCellID) {
+		if id.Row != 0 {
+			if onSelected != nil {
+				onSelected(id)
+			}
+			return
+		}
+		table.UnselectAll()
+		s.toggle(id.Col)
+		resort()
+	}
+}
+
+// sortedBy переставляет items в порядке order
+func sortedBy[T any](items []T, order []int) []T {
+	sorted := make([]T, len(order))
+	for i, index := range order {
+		sorted[i] = items[index]
+	}
+	return sorted
+}
+
+// compareCells сравнивает ячейки с учётом чисел: места, очки, круги, время ("1:31:44.742", "+5.123")
+// и скорость ("218.300 kph") сравниваются как числа и идут раньше текста
+func compareCells(a, b string) int {
+	x, aNumber := cellNumber(a)
+	y, bNumber := cellNumber(b)
+	switch {
+	case aNumber && bNumber:
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+		return 0
+	case aNumber:
+		return -1
+	case bNumber:
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// cellNumber разбирает числовую ячейку
+func cellNumber(s string) (float64, bool) {
+	if value, unit, ok := strings.Cut(s, " "); ok && (unit == "kph" || unit == "mph") {
+		s = value
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "+"), "s")
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n, true
+	}
+	if d, ok := ergast.ParseLapTime(s); ok {
+		return d.Seconds(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"hash/fnv"
+	"image/color"
+)
+
+// teamColors — фирменные цвета команд по constructorId Ergast (для графиков)
+var teamColors = map[string]color.NRGBA{
+	"red_bull":     {R: 0x36, G: 0x71, B: 0xc6, A: 0xff},
+	"ferrari":      {R: 0xe8, G: 0x00, B: 0x20, A: 0xff},
+	"mercedes":     {R: 0x27, G: 0xf4, B: 0xd2, A: 0xff},
+	"mclaren":      {R: 0xff, G: 0x80, B: 0x00, A: 0xff},
+	"aston_martin": {R: 0x22, G: 0x99, B: 0x71, A: 0xff},
+	"alpine":       {R: 0x00, G: 0x93, B: 0xcc, A: 0xff},
+	"williams":     {R: 0x64, G: 0xc4, B: 0xff, A: 0xff},
+	"rb":           {R: 0x66, G: 0x92, B: 0xff, A: 0xff},
+	"alphatauri":   {R: 0x5e, G: 0x8f, B: 0xaa, A: 0xff},
+	"toro_rosso":   {R: 0x46, G: 0x9b, B: 0xff, A: 0xff},
+	"sauber":       {R: 0x52, G: 0xe2, B: 0x52, A: 0xff},
+	"alfa":         {R: 0xb1, G: 0x20, B: 0x39, A: 0xff},
+	"haas":         {R: 0xb6, G: 0xba, B: 0xbd, A: 0xff},
+	"renault":      {R: 0xff, G: 0xf5, B: 0x00, A: 0xff},
+	"racing_point": {R: 0xf5, G: 0x96, B: 0xc8, A: 0xff},
+	"force_india":  {R: 0xf5, G: 0x96, B: 0xc8, A: 0xff},
+	"lotus_f1":     {R: 0xff, G: 0xb8, B: 0x00, A: 0xff},
+	"manor":        {R: 0x32, G: 0x32, B: 0x32, A: 0xff},
+	"brawn":        {R: 0xb8, G: 0xfd, B: 0x6e, A: 0xff},
+	"toyota":       {R: 0xcc, G: 0x00, B: 0x00, A: 0xff},
+	"bmw_sauber":   {R: 0x00, G: 0x52, B: 0x9b, A: 0xff},
+	"honda":        {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	"jordan":       {R: 0xff, G: 0xd7, B: 0x00, A: 0xff},
+	"jaguar":       {R: 0x0a, G: 0x5c, B: 0x2e, A: 0xff},
+	"bar":          {R: 0xd0, G: 0xd0, B: 0xd0, A: 0xff},
+	"minardi":      {R: 0x20, G: 0x20, B: 0x20, A: 0xff},
+}
+
+// fallbackColors — для команд, которых нет в teamColors (в основном старые сезоны)
+var fallbackColors = []color.NRGBA{
+	{R: 0xe6, G: 0x19, B: 0x4b, A: 0xff},
+	{R: 0x3c, G: 0xb4, B: 0x4b, A: 0xff},
+	{R: 0x43, G: 0x63, B: 0xd8, A: 0xff},
+	{R: 0xf5, G: 0x82, B: 0x31, A: 0xff},
+	{R: 0x91, G: 0x1e, B: 0xb4, A: 0xff},
+	{R: 0x42, G: 0xd4, B: 0xf4, A: 0xff},
+	{R: 0xf0, G: 0x32, B: 0xe6, A: 0xff},
+	{R: 0xbf, G: 0xef, B: 0x45, A: 0xff},
+	{R: 0x46, G: 0x99, B: 0x90, A: 0xff},
+	{R: 0x9a, G: 0x63, B: 0x24, A: 0xff},
+}
+
+// teamColor возвращает цвет команды; у неизвестных команд цвет стабильно выводится из id
+func teamColor(constructorID string) color.NRGBA {
+	if c, ok := teamColors[constructorID]; ok {
+		return c
+	}
+	h := fnv.New32a()
+	h.Write([]byte(constructorID))
+	return fallbackColors[h.Sum32()%uint32(len(fallbackColors))]
+}
+
+// secondDriverColor — цвет второго пилота команды: тот же оттенок, но темнее, чтобы линии различались
+func secondDriverColor(c color.NRGBA) color.NRGBA {
+	return color.NRGBA{R: c.R / 2, G: c.G / 2, B: c.B / 2, A: c.A}
+}
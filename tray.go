@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+)
+
+// winnerRetry — как часто переспрашивать результаты, пока победитель последней гонки не опубликован
+const winnerRetry = 5 * time.Minute
+
+// tray — меню в системном трее: ближайшая сессия, обратный отсчёт, последний победитель
+// и быстрый переход к сезону или этапу. Пока оно есть, приложение живёт и без открытых окон
+type tray struct {
+	app  *App
+	desk desktop.App
+
+	labels string // подписи, с которыми меню собрано в последний раз
+
+	winner          string // "Max Verstappen (Japanese Grand Prix)"
+	winnerRace      string // сезон/этап, для которого загружен winner
+	winnerLoading   bool
+	winnerCheckedAt time.Time
+}
+
+// setupTray добавляет меню в трей. false — трей не включён в настройках или драйвер его
+// не поддерживает (браузер, мобильные). Включается вручную: без области уведомлений
+// (GNOME из коробки) приложение осталось бы без окон и без способа выйти
+func (a *App) setupTray() bool {
+	if keep, _ := a.keepInTray.Get(); !keep {
+		return false
+	}
+	if a.tray != nil {
+		return true // уже добавлено — второй раз меню не собираем
+	}
+	desk, ok := a.fyneApp.(desktop.App)
+	if !ok {
+		return false
+	}
+	t := &tray{app: a, desk: desk}
+	desk.SetSystemTrayIcon(theme.CalendarIcon())
+	t.refresh(time.Now())
+	a.clockHandlers[t] = t.refresh
+	a.tray = t
+	return true
+}
+
+// enableTray включает трей без перезапуска. Главное окно (оно есть, если трей был выключен
+// при запуске) при закрытии завершает приложение, поэтому теперь оно только прячется
+func (a *App) enableTray() bool {
+	if !a.setupTray() {
+		return false
+	}
+	if master := a.masterWindow; master != nil {
+		master.SetCloseIntercept(func() {
+			if keep, _ := a.keepInTray.Get(); keep {
+				master.Hide()
+				return
+			}
+			master.Close()
+		})
+	}
+	return true
+}
+
+// setupMenu добавляет окну меню: новое окно, настройка трея и явный выход.
+// Возвращает слушатель настройки, который нужно снять при закрытии окна
+func (v *SeasonView) setupMenu() binding.DataListener {
+	keepInTray := fyne.NewMenuItem("Keep Running in System Tray", func() {
+		keep, _ := v.app.keepInTray.Get()
+		keep = !keep
+		v.app.keepInTray.Set(keep)
+		switch {
+		case keep && !v.app.enableTray():
+			v.app.keepInTray.Set(false)
+			dialog.ShowInformation("System Tray", "The system tray is not supported on this platform.", v.window)
+		case !keep && v.app.tray != nil: // Fyne не умеет убирать значок из трея
+			dialog.ShowInformation("System Tray", "The tray icon disappears after restarting the app.", v.window)
+		}
+	})
+	quit := fyne.NewMenuItem("Quit", v.app.fyneApp.Quit)
+	quit.IsQuit = true
+	menu := fyne.NewMainMenu(fyne.NewMenu("File",
+		fyne.NewMenuItem("New Window", func() { v.app.NewSeasonWindow() }),
+		fyne.NewMenuItemSeparator(),
+		keepInTray,
+		fyne.NewMenuItemSeparator(),
+		quit,
+	))
+	v.window.SetMainMenu(menu)
+
+	listener := binding.NewDataListener(func() { // настройку могли поменять в другом окне
+		keepInTray.Checked, _ = v.app.keepInTray.Get()
+		menu.Refresh()
+	})
+	v.app.keepInTray.AddListener(listener)
+	return listener
+}
+
+// refresh пересобирает меню, когда меняются подписи (обратный отсчёт — с точностью до минуты)
+func (t *tray) refresh(now time.Time) {
+	t.checkWinner(now)
+
+	nextLabel, countdownLabel := "Next: loading the current season...", ""
+	if next, ok := nextSession(t.app.schedule, now); ok {
+		nextLabel = fmt.Sprintf("Next: %s — %s", next.race.RaceName, next.session.Name)
+		countdownLabel = "Starts in " + formatCountdownMinutes(next.session.Start.Sub(now))
+	} else if !t.app.scheduleLoadedAt.IsZero() {
+		nextLabel = "Next: no upcoming sessions"
+	}
+	winnerLabel := "Latest winner: no races finished yet this season"
+	if t.winner != "" {
+		winnerLabel = "Latest winner: " + t.winner
+	}
+	season := t.season(now)
+
+	labels := nextLabel + countdownLabel + winnerLabel + season + fmt.Sprint(len(t.app.schedule))
+	if labels == t.labels {
+		return
+	}
+	t.labels = labels
+
+	items := []*fyne.MenuItem{{Label: nextLabel, Disabled: true}}
+	if countdownLabel != "" {
+		items = append(items, &fyne.MenuItem{Label: countdownLabel, Disabled: true})
+	}
+	items = append(items, &fyne.MenuItem{Label: winnerLabel, Disabled: true}, fyne.NewMenuItemSeparator())
+	if season != "" {
+		items = append(items, fyne.NewMenuItem("Open Season "+season, func() { t.app.OpenSeason(season, "") }))
+		var rounds []*fyne.MenuItem
+		for _, race := range t.app.schedule {
+			if race.Season != season {
+				continue
+			}
+			round := race.Round
+			rounds = append(rounds, fyne.NewMenuItem(fmt.Sprintf("Round %s: %s", race.Round, race.RaceName), func() {
+				t.app.OpenSeason(season, round)
+			}))
+		}
+		openRound := fyne.NewMenuItem("Open Round", nil)
+		openRound.ChildMenu = fyne.NewMenu("", rounds...)
+		items = append(items, openRound)
+	}
+	items = append(items, fyne.NewMenuItem("New Window", func() { t.app.NewSeasonWindow() }))
+	t.desk.SetSystemTrayMenu(fyne.NewMenu("F1 Race Catalog", items...)) // пункт Quit Fyne добавляет сам
+}
+
+// season — сезон ближайшей сессии, а после конца сезона — последний в календаре
+func (t *tray) season(now time.Time) string {
+	if next, ok := nextSession(t.app.schedule, now); ok {
+		return next.race.Season
+	}
+	if len(t.app.schedule) > 0 {
+		return t.app.schedule[len(t.app.schedule)-1].Season
+	}
+	return ""
+}
+
+// checkWinner загружает победителя последней завершившейся гонки календаря
+func (t *tray) checkWinner(now time.Time) {
+	var last *ergast.Race
+	for i, race := range t.app.schedule {
+		if start, ok := race.Start(); ok && start.Add(sessionDurations["Race"]).Before(now) {
+			last = &t.app.schedule[i]
+		}
+	}
+	if last == nil || t.winnerLoading || last.Season+"/"+last.Round == t.winnerRace || now.Sub(t.winnerCheckedAt) < winnerRetry {
+		return
+	}
+	race := *last
+	t.winnerLoading = true
+	go func() {
+		results, err := t.app.client.Results(context.Background(), race.Season, race.Round)
+		fyne.Do(func() {
+			t.winnerLoading = false
+			t.winnerCheckedAt = time.Now()
+			if err != nil {
+				fmt.Println("Error loading latest winner:", err)
+				return
+			}
+			if len(results) == 0 {
+				return // результаты ещё не опубликованы — спросим позже
+			}
+			t.winner = fmt.Sprintf("%s (%s)", driverName(results[0].Driver), race.RaceName)
+			t.winnerRace = race.Season + "/" + race.Round
+		})
+	}()
+}
+
+// formatCountdownMinutes — оставшееся время с точностью до минуты: "1d 02h 03m"
+func formatCountdownMinutes(d time.Duration) string {
+	d = max(d, 0)
+	days := int(d / (24 * time.Hour))
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	if days > 0 {
+		return fmt.Sprintf("%dd %02dh %02dm", days, hours, minutes)
+	}
+	return fmt.Sprintf("%02dh %02dm", hours, minutes)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"F1_catalog/ergast"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// За сколько минут до сессии напоминать
+var notifyMinuteOptions = []string{"5", "15", "30", "60"}
+
+const defaultNotifyMinutes = 15
+
+// scheduleRefresh — как часто перечитывать календарь текущего сезона (сеть сдерживает кэш)
+const scheduleRefresh = time.Hour
+
+// upcoming — сессия календаря вместе с её этапом
+type upcoming struct {
+	race    ergast.Race
+	session ergast.NamedSession
+}
+
+// nextSession — первая ещё не начавшаяся сессия с известным временем
+func nextSession(races []ergast.Race, now time.Time) (upcoming, bool) {
+	for _, race := range races {
+		for _, session := range race.Sessions() {
+			if session.HasTime && session.Start.After(now) {
+				return upcoming{race: race, session: session}, true
+			}
+		}
+	}
+	return upcoming{}, false
+}
+
+// upcomingRaceIndex — первая гонка, которая ещё не закончилась; -1, если сезон завершён
+func upcomingRaceIndex(races []ergast.Race, now time.Time) int {
+	for i, race := range races {
+		if start, ok := race.Start(); ok && start.Add(sessionDurations["Race"]).After(now) {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatCountdown — оставшееся время вида "2d 04:05:06"
+func formatCountdown(d time.Duration) string {
+	d = max(d, 0).Truncate(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	clock := fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	if days > 0 {
+		return fmt.Sprintf("%dd %s", days, clock)
+	}
+	return clock
+}
+
+// startClock загружает календарь текущего сезона и раз в секунду обновляет обратные отсчёты
+// и рассылает напоминания. Вызывается, когда приложение запущено
+func (a *App) startClock() {
+	a.loadSchedule()
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		for now := range ticker.C {
+			fyne.Do(func() { a.tick(now) })
+		}
+	}()
+}
+
+// tick выполняется в UI-потоке каждую секунду
+func (a *App) tick(now time.Time) {
+	if !a.scheduleLoading && now.Sub(a.scheduleLoadedAt) > scheduleRefresh {
+		a.loadSchedule()
+	}
+	a.sendNotifications(now)
+	for _, handler := range a.clockHandlers {
+		handler(now)
+	}
+}
+
+// loadSchedule читает календарь текущего сезона, а если в нём не осталось сессий — и следующего
+func (a *App) loadSchedule() {
+	a.scheduleLoading = true
+	go func() {
+		now := time.Now()
+		var races []ergast.Race
+		for year := now.Year(); year <= now.Year()+1; year++ {
+			season, err := a.client.Season(context.Background(), strconv.Itoa(year))
+			if err != nil {
+				fmt.Println("Error loading schedule:", err)
+				break
+			}
+			races = append(races, season...)
+			if _, ok := nextSession(races, now); ok {
+				break
+			}
+		}
+		fyne.Do(func() {
+			a.scheduleLoading = false
+			a.scheduleLoadedAt = time.Now()
+			if len(races) > 0 { // без сети оставляем прежний календарь
+				a.schedule = races
+			}
+		})
+	}()
+}
+
+// sendNotifications напоминает о сессиях, до которых осталось не больше выбранного числа минут
+func (a *App) sendNotifications(now time.Time) {
+	if enabled, _ := a.notify.Get(); !enabled {
+		return
+	}
+	minutes, _ := a.notifyMinutes.Get()
+	lead := time.Duration(minutes) * time.Minute
+	for _, race := range a.schedule {
+		for _, session := range race.Sessions() {
+			key := race.Season + "/" + race.Round + "/" + session.Name
+			if !session.HasTime || a.notified[key] || now.Before(session.Start.Add(-lead)) || !now.Before(session.Start) {
+				continue
+			}
+			a.notified[key] = true
+			left := session.Start.Sub(now).Round(time.Minute)
+			a.fyneApp.SendNotification(fyne.NewNotification(
+				fmt.Sprintf("%s starts in %d min", session.Name, int(left.Minutes())),
+				fmt.Sprintf("%s — %s", race.RaceName, formatSessionTime(session, race.Circuit.Location.TimeZone(), a.primaryTime())),
+			))
+		}
+	}
+}
+
+// nextUpPanel — строка "Next up" с обратным отсчётом до ближайшей сессии и настройкой напоминаний
+type nextUpPanel struct {
+	app *App
+
+	title     *widget.Label
+	countdown *widget.Label
+	minutes   *widget.Select
+}
+
+func newNextUpPanel(a *App) *nextUpPanel {
+	p := &nextUpPanel{app: a, title: widget.NewLabel(""), countdown: widget.NewLabel("")}
+	p.title.Truncation = fyne.TextTruncateEllipsis
+	p.countdown.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+	p.minutes = widget.NewSelect(notifyMinuteOptions, func(selected string) {
+		if minutes, err := strconv.Atoi(selected); err == nil {
+			a.notifyMinutes.Set(minutes)
+		}
+	})
+	p.refresh(time.Now())
+	return p
+}
+
+func (p *nextUpPanel) content() fyne.CanvasObject {
+	notifyCheck := widget.NewCheckWithData("Notify", p.app.notify)
+	settings := container.NewHBox(p.countdown, notifyCheck, p.minutes, widget.NewLabel("min before"))
+	return container.NewBorder(nil, nil, widget.NewIcon(theme.HistoryIcon()), settings, p.title)
+}
+
+// refresh показывает ближайшую сессию и оставшееся до неё время. Вызывается каждую секунду
+func (p *nextUpPanel) refresh(now time.Time) {
+	// выбор могли поменять в другом окне; SetSelected вызывает OnChanged, поэтому только при расхождении
+	minutes, _ := p.app.notifyMinutes.Get()
+	if selected := strconv.Itoa(minutes); selected != p.minutes.Selected {
+		p.minutes.SetSelected(selected)
+	}
+
+	next, ok := nextSession(p.app.schedule, now)
+	switch {
+	case ok:
+		p.title.SetText(fmt.Sprintf("Next up: %s — %s, %s", next.race.RaceName, next.session.Name,
+			formatSessionTime(next.session, next.race.Circuit.Location.TimeZone(), p.app.primaryTime())))
+		p.countdown.SetText("in " + formatCountdown(next.session.Start.Sub(now)))
+	case p.app.scheduleLoadedAt.IsZero():
+		p.title.SetText("Next up: loading the current season...")
+		p.countdown.SetText("")
+	default:
+		p.title.SetText("Next up: no upcoming sessions in the calendar.")
+		p.countdown.SetText("")
+	}
+}